@@ -0,0 +1,545 @@
+package chess
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// The binary game format is made of a fixed header followed by optional
+// sections. All integers are unsigned varints and all strings are prefixed
+// by their length.
+//
+//	version  byte       binaryGameVersion
+//	flags    byte       which optional sections are present
+//	outcome  byte       0: *, 1: 1-0, 2: 0-1, 3: 1/2-1/2
+//	method   byte       Method of the game
+//	position [101]byte  Position.MarshalBinary of the start, if flagStartPosition
+//	tags     count, (key, value)*                           if flagTags
+//	moves    count, index*                                  without flagVariations
+//	         count, (index, subtree)*                       with flagVariations
+//	comments count, (node, comment, nags)*                  if flagComments
+//	commands count, (node, count, (key, value)*)*           if flagCommands
+//	game     count, (count, comment*)*                      if flagGameComments
+//
+// Each move is stored as a single byte: its index in the ValidMoves() list of
+// the position it is played from. With flagVariations the whole move tree
+// is written depth first, each node listing its children. Annotated nodes
+// are referenced by their depth first index, the root being 0. The game
+// comments section holds the comments returned by Game.Comments.
+
+const binaryGameVersion = 1
+
+// maxBinaryGameLen is the largest length prefix accepted by
+// BinaryGameReader, so that a corrupt stream can't force a huge allocation.
+const maxBinaryGameLen = 16 << 20
+
+const (
+	flagStartPosition uint8 = 1 << iota
+	flagTags
+	flagVariations
+	flagComments
+	flagCommands
+	flagGameComments
+)
+
+//nolint:gochecknoglobals // lookup table.
+var binaryOutcomes = []Outcome{NoOutcome, WhiteWon, BlackWon, Draw}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface and
+// encodes the game in a compact binary format. Standard starting positions
+// are not stored and moves take a single byte each.
+func (g *Game) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+
+	var flags uint8
+	startPos := g.rootMove.position
+	if !startPos.samePosition(StartingPosition()) || startPos.halfMoveClock != 0 || startPos.moveCount != 1 {
+		flags |= flagStartPosition
+	}
+	if len(g.tagPairs) > 0 {
+		flags |= flagTags
+	}
+	var hasComments, hasCommands bool
+	walkMoves(g.rootMove, func(m *Move) {
+		if len(m.children) > 1 {
+			flags |= flagVariations
+		}
+		hasComments = hasComments || m.comments != "" || len(m.nags) > 0
+		hasCommands = hasCommands || len(m.command) > 0
+	})
+	if hasComments {
+		flags |= flagComments
+	}
+	if hasCommands {
+		flags |= flagCommands
+	}
+	if len(g.comments) > 0 {
+		flags |= flagGameComments
+	}
+
+	outcome := slices.Index(binaryOutcomes, g.outcome)
+	if outcome < 0 {
+		outcome = 0
+	}
+	buf.Write([]byte{binaryGameVersion, flags, byte(outcome), byte(g.method)})
+
+	if flags&flagStartPosition != 0 {
+		b, err := startPos.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+
+	if flags&flagTags != 0 {
+		keys := make([]string, 0, len(g.tagPairs))
+		for k := range g.tagPairs {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		writeUvarint(&buf, uint64(len(keys)))
+		for _, k := range keys {
+			writeString(&buf, k)
+			writeString(&buf, g.tagPairs[k])
+		}
+	}
+
+	if flags&flagVariations != 0 {
+		if err := writeMoveTree(&buf, g.rootMove); err != nil {
+			return nil, err
+		}
+	} else {
+		moves := g.Moves()
+		writeUvarint(&buf, uint64(len(moves)))
+		for _, m := range moves {
+			idx, err := moveIndex(m.parent.position, m)
+			if err != nil {
+				return nil, err
+			}
+			buf.WriteByte(idx)
+		}
+	}
+
+	if flags&(flagComments|flagCommands) != 0 {
+		var commented, commanded []int
+		var nodes []*Move
+		walkMoves(g.rootMove, func(m *Move) {
+			if m.comments != "" || len(m.nags) > 0 {
+				commented = append(commented, len(nodes))
+			}
+			if len(m.command) > 0 {
+				commanded = append(commanded, len(nodes))
+			}
+			nodes = append(nodes, m)
+		})
+
+		if flags&flagComments != 0 {
+			writeUvarint(&buf, uint64(len(commented)))
+			for _, i := range commented {
+				writeUvarint(&buf, uint64(i))
+				writeString(&buf, nodes[i].comments)
+				writeString(&buf, strings.Join(nodes[i].nags, " "))
+			}
+		}
+		if flags&flagCommands != 0 {
+			writeUvarint(&buf, uint64(len(commanded)))
+			for _, i := range commanded {
+				writeUvarint(&buf, uint64(i))
+				keys := make([]string, 0, len(nodes[i].command))
+				for k := range nodes[i].command {
+					keys = append(keys, k)
+				}
+				slices.Sort(keys)
+				writeUvarint(&buf, uint64(len(keys)))
+				for _, k := range keys {
+					writeString(&buf, k)
+					writeString(&buf, nodes[i].command[k])
+				}
+			}
+		}
+	}
+
+	if flags&flagGameComments != 0 {
+		writeUvarint(&buf, uint64(len(g.comments)))
+		for _, comments := range g.comments {
+			writeUvarint(&buf, uint64(len(comments)))
+			for _, c := range comments {
+				writeString(&buf, c)
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface and
+// decodes a game encoded by MarshalBinary. The current move of the game is
+// the last move of the main line.
+func (g *Game) UnmarshalBinary(data []byte) error {
+	const headerLen = 4
+	const positionLen = 101
+
+	if len(data) < headerLen {
+		return errors.New("chess: game binary data too short")
+	}
+	if data[0] != binaryGameVersion {
+		return fmt.Errorf("chess: unsupported game binary version %d", data[0])
+	}
+	flags := data[1]
+	if int(data[2]) >= len(binaryOutcomes) || Method(data[3]) > InsufficientMaterial {
+		return errors.New("chess: invalid game binary outcome")
+	}
+
+	game := NewGame()
+	game.outcome = binaryOutcomes[data[2]]
+	game.method = Method(data[3])
+	r := bytes.NewReader(data[headerLen:])
+
+	if flags&flagStartPosition != 0 {
+		b := make([]byte, positionLen)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return err
+		}
+		pos := &Position{}
+		if err := pos.UnmarshalBinary(b); err != nil {
+			return err
+		}
+		game.rootMove.position = pos
+	}
+
+	if flags&flagTags != 0 {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		for range n {
+			k, err := readString(r)
+			if err != nil {
+				return err
+			}
+			v, err := readString(r)
+			if err != nil {
+				return err
+			}
+			game.tagPairs[k] = v
+		}
+	}
+
+	if flags&flagVariations != 0 {
+		if err := readMoveTree(r, game.rootMove); err != nil {
+			return err
+		}
+	} else {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		cur := game.rootMove
+		for range n {
+			idx, err := r.ReadByte()
+			if err != nil {
+				return err
+			}
+			if cur, err = appendIndexedMove(cur, idx); err != nil {
+				return err
+			}
+		}
+	}
+
+	if flags&(flagComments|flagCommands) != 0 {
+		var nodes []*Move
+		walkMoves(game.rootMove, func(m *Move) {
+			nodes = append(nodes, m)
+		})
+
+		if flags&flagComments != 0 {
+			if err := readAnnotations(r, nodes, func(m *Move) error {
+				var err error
+				if m.comments, err = readString(r); err != nil {
+					return err
+				}
+				nags, err := readString(r)
+				m.nags = strings.Fields(nags)
+				return err
+			}); err != nil {
+				return err
+			}
+		}
+		if flags&flagCommands != 0 {
+			if err := readAnnotations(r, nodes, func(m *Move) error {
+				n, err := binary.ReadUvarint(r)
+				if err != nil {
+					return err
+				}
+				for range n {
+					k, err := readString(r)
+					if err != nil {
+						return err
+					}
+					v, err := readString(r)
+					if err != nil {
+						return err
+					}
+					m.SetCommand(k, v)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if flags&flagGameComments != 0 {
+		comments, err := readGameComments(r)
+		if err != nil {
+			return err
+		}
+		game.comments = comments
+	}
+
+	game.currentMove = game.rootMove
+	for len(game.currentMove.children) > 0 {
+		game.currentMove = game.currentMove.children[0]
+	}
+	game.pos = game.currentMove.position
+
+	g.copy(game)
+	return nil
+}
+
+// readGameComments reads the game comments section.
+func readGameComments(r *bytes.Reader) ([][]string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	comments := make([][]string, 0, n)
+	for range n {
+		count, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if count > uint64(r.Len()) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		group := make([]string, 0, count)
+		for range count {
+			c, err := readString(r)
+			if err != nil {
+				return nil, err
+			}
+			group = append(group, c)
+		}
+		comments = append(comments, group)
+	}
+	return comments, nil
+}
+
+// BinaryGameWriter writes games in the binary format of Game.MarshalBinary
+// to an underlying writer. Each game is prefixed by its length so that many
+// games can be stored in a single stream. Writes are buffered: Flush must be
+// called once all games have been written.
+type BinaryGameWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// NewBinaryGameWriter returns a writer of binary games to w.
+func NewBinaryGameWriter(w io.Writer) *BinaryGameWriter {
+	return &BinaryGameWriter{w: bufio.NewWriter(w)}
+}
+
+// Write encodes and writes the game. Once an error occurred, every
+// following call returns that error.
+func (bw *BinaryGameWriter) Write(g *Game) error {
+	if bw.err != nil {
+		return bw.err
+	}
+	data, err := g.MarshalBinary()
+	if err != nil {
+		bw.err = err
+		return err
+	}
+	var prefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(prefix[:], uint64(len(data)))
+	if _, err = bw.w.Write(prefix[:n]); err == nil {
+		_, err = bw.w.Write(data)
+	}
+	bw.err = err
+	return err
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (bw *BinaryGameWriter) Flush() error {
+	if bw.err != nil {
+		return bw.err
+	}
+	bw.err = bw.w.Flush()
+	return bw.err
+}
+
+// BinaryGameReader reads games written by a BinaryGameWriter.
+type BinaryGameReader struct {
+	r *bufio.Reader
+}
+
+// NewBinaryGameReader returns a reader of binary games from r.
+func NewBinaryGameReader(r io.Reader) *BinaryGameReader {
+	return &BinaryGameReader{r: bufio.NewReader(r)}
+}
+
+// Read reads and decodes the next game.
+// It returns nil and io.EOF when no more games are available.
+//
+// Example:
+//
+//	for {
+//	    game, err := reader.Read()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    // Process game
+//	}
+func (br *BinaryGameReader) Read() (*Game, error) {
+	n, err := binary.ReadUvarint(br.r)
+	if err != nil {
+		return nil, err
+	}
+	if n > maxBinaryGameLen {
+		return nil, fmt.Errorf("chess: binary game length %d exceeds the maximum of %d bytes", n, maxBinaryGameLen)
+	}
+	// the buffer grows with the data actually read, not with the prefix
+	var data bytes.Buffer
+	if _, err := io.CopyN(&data, br.r, int64(n)); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	g := NewGame()
+	if err := g.UnmarshalBinary(data.Bytes()); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// walkMoves calls fn for m and all of its descendants, depth first with
+// the main line before the variations.
+func walkMoves(m *Move, fn func(*Move)) {
+	fn(m)
+	for _, c := range m.children {
+		walkMoves(c, fn)
+	}
+}
+
+// moveIndex returns the index of m in the valid moves of pos.
+func moveIndex(pos *Position, m *Move) (byte, error) {
+	for i, vm := range pos.ValidMoves() {
+		if vm.s1 == m.s1 && vm.s2 == m.s2 && vm.promo == m.promo {
+			return byte(i), nil
+		}
+	}
+	return 0, fmt.Errorf("chess: move %s is not valid in position %s", m, pos)
+}
+
+// appendIndexedMove appends the valid move of index idx of parent's position.
+func appendIndexedMove(parent *Move, idx byte) (*Move, error) {
+	moves := parent.position.ValidMoves()
+	if int(idx) >= len(moves) {
+		return nil, fmt.Errorf("chess: invalid move index %d in position %s", idx, parent.position)
+	}
+	return parent.appendChild(&moves[idx]), nil
+}
+
+func writeMoveTree(buf *bytes.Buffer, m *Move) error {
+	writeUvarint(buf, uint64(len(m.children)))
+	for _, c := range m.children {
+		idx, err := moveIndex(m.position, c)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(idx)
+		if err := writeMoveTree(buf, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readMoveTree(r *bytes.Reader, m *Move) error {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	for range n {
+		idx, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		child, err := appendIndexedMove(m, idx)
+		if err != nil {
+			return err
+		}
+		if err := readMoveTree(r, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readAnnotations reads a list of node references and calls fn with the
+// referenced node for each of them.
+func readAnnotations(r *bytes.Reader, nodes []*Move, fn func(*Move) error) error {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	for range n {
+		i, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		if i >= uint64(len(nodes)) {
+			return fmt.Errorf("chess: invalid annotated move index %d", i)
+		}
+		if err := fn(nodes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	buf.Write(b[:n])
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	writeUvarint(buf, uint64(len(s)))
+	buf.WriteString(s)
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	if n > uint64(r.Len()) {
+		return "", io.ErrUnexpectedEOF
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,168 @@
+package chess
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGameBinaryRoundTrip(t *testing.T) {
+	for _, fname := range []string{
+		"fixtures/pgns/complete_game.pgn",
+		"fixtures/pgns/variations.pgn",
+		"fixtures/pgns/single_frompos.pgn",
+		"fixtures/pgns/lichess_multiple_command.pgn",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			f, err := os.Open(fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			opt, err := PGN(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := NewGame(opt)
+
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded := NewGame()
+			if err := decoded.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			assertSameGame(t, g, decoded)
+			if decoded.Outcome() != g.Outcome() || decoded.Method() != g.Method() {
+				t.Fatalf("expected %s by %s but got %s by %s", g.Outcome(), g.Method(), decoded.Outcome(), decoded.Method())
+			}
+		})
+	}
+}
+
+func TestGameBinaryCompactMainline(t *testing.T) {
+	g := NewGame()
+	for _, m := range []string{"e4", "e5", "Nf3", "Nc6", "Bb5"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header + move count + one byte per move
+	if expected := 4 + 1 + 5; len(data) != expected {
+		t.Fatalf("expected %d bytes but got %d", expected, len(data))
+	}
+}
+
+func TestGameBinaryGameComments(t *testing.T) {
+	g := NewGame()
+	data := `{"comments":[["first","second"],[],["third"]],"moves":[{"uci":"e2e4"}],"result":"*"}`
+	if err := json.Unmarshal([]byte(data), g); err != nil {
+		t.Fatal(err)
+	}
+	b, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := NewGame()
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Comments(), g.Comments()) {
+		t.Fatalf("expected comments %q but got %q", g.Comments(), decoded.Comments())
+	}
+	if err := NewGame().UnmarshalBinary(b[:len(b)-1]); err == nil {
+		t.Fatalf("expected error for truncated game comments")
+	}
+}
+
+func TestGameBinaryInvalidData(t *testing.T) {
+	if err := NewGame().UnmarshalBinary([]byte{binaryGameVersion, 0, 0}); err == nil {
+		t.Fatalf("expected error for truncated header")
+	}
+	if err := NewGame().UnmarshalBinary([]byte{99, 0, 0, 0, 0}); err == nil {
+		t.Fatalf("expected error for unknown version")
+	}
+	if err := NewGame().UnmarshalBinary([]byte{binaryGameVersion, 0, 0, 0, 1, 200}); err == nil {
+		t.Fatalf("expected error for invalid move index")
+	}
+}
+
+func TestBinaryGameStream(t *testing.T) {
+	f, err := os.Open("fixtures/pgns/multi_game.pgn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var games []*Game
+	scanner := NewScanner(f)
+	for scanner.HasNext() {
+		g, err := scanner.ParseNext()
+		if err != nil {
+			t.Fatal(err)
+		}
+		games = append(games, g)
+	}
+
+	var buf bytes.Buffer
+	w := NewBinaryGameWriter(&buf)
+	for _, g := range games {
+		if err := w.Write(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewBinaryGameReader(&buf)
+	for i, expected := range games {
+		g, err := r.Read()
+		if err != nil {
+			t.Fatalf("game %d: %v", i, err)
+		}
+		assertSameGame(t, expected, g)
+	}
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF but got %v", err)
+	}
+}
+
+func TestBinaryGameReaderInvalidLength(t *testing.T) {
+	// a length prefix of 2^40 bytes followed by a few bytes only
+	prefix := []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x20}
+	if _, err := NewBinaryGameReader(bytes.NewReader(prefix)).Read(); err == nil {
+		t.Fatal("expected an error for an oversized game")
+	}
+
+	truncated := []byte{0x10, 0x01, 0x00}
+	if _, err := NewBinaryGameReader(bytes.NewReader(truncated)).Read(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF but got %v", err)
+	}
+}
+
+// assertSameGame compares games through their JSON encoding which,
+// unlike their PGN, writes commands in a stable order.
+func assertSameGame(t *testing.T, expected, actual *Game) {
+	t.Helper()
+	a, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Fatalf("expected game\n%s\nbut got\n%s", a, b)
+	}
+}
@@ -291,6 +291,42 @@ func (b *Board) Piece(sq Square) Piece {
 	return NoPiece
 }
 
+// Attacks returns the squares attacked by the piece on sq, whether they are
+// empty or occupied by a piece of either color. Pins are ignored. It returns
+// nil for an empty square.
+func (b *Board) Attacks(sq Square) []Square {
+	p := b.Piece(sq)
+	occ := ^b.emptySqs
+	var bb bitboard
+	switch p.Type() {
+	case King:
+		bb = bbKingMoves[sq]
+	case Queen:
+		bb = diaAttack(occ, sq) | hvAttack(occ, sq)
+	case Rook:
+		bb = hvAttack(occ, sq)
+	case Bishop:
+		bb = diaAttack(occ, sq)
+	case Knight:
+		bb = bbKnightMoves[sq]
+	case Pawn:
+		if p.Color() == White {
+			bb = (bbForSquare(sq)&^bbFileH&^bbRank8)>>9 | (bbForSquare(sq)&^bbFileA&^bbRank8)>>7
+		} else {
+			bb = (bbForSquare(sq)&^bbFileH&^bbRank1)<<7 | (bbForSquare(sq)&^bbFileA&^bbRank1)<<9
+		}
+	default:
+		return nil
+	}
+	var squares []Square
+	for s := range Square(numOfSquaresInBoard) {
+		if bb.Occupied(s) {
+			squares = append(squares, s)
+		}
+	}
+	return squares
+}
+
 // MarshalText implements the encoding.TextMarshaler interface and returns
 // a string in the FEN board format: rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR.
 func (b *Board) MarshalText() ([]byte, error) {
@@ -1,6 +1,7 @@
 package chess
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -88,3 +89,23 @@ func TestBoardTranspose(t *testing.T) {
 		t.Fatalf("expected board string %s but got %s", b, board.String())
 	}
 }
+
+func TestBoardAttacks(t *testing.T) {
+	b := unsafeFEN("4k3/8/8/3p4/8/1n6/P2R4/4K3 w - - 0 1").Board()
+	tests := []struct {
+		sq       Square
+		expected string
+	}{
+		{A2, "[b3]"},
+		{D5, "[c4 e4]"},
+		{B3, "[a1 c1 d2 d4 a5 c5]"},
+		{D2, "[d1 a2 b2 c2 e2 f2 g2 h2 d3 d4 d5]"},
+		{E1, "[d1 f1 d2 e2 f2]"},
+		{C4, "[]"},
+	}
+	for _, test := range tests {
+		if got := fmt.Sprint(b.Attacks(test.sq)); got != test.expected {
+			t.Errorf("expected %s to attack %s, got %s", test.sq, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package cql
+
+import (
+	"github.com/corentings/chess/v2"
+)
+
+// A predicate is a compiled filter over a position and the move that led
+// to it, which may be nil.
+type predicate func(pos *chess.Position, last *chess.Move) bool
+
+func and(a, b predicate) predicate {
+	return func(pos *chess.Position, last *chess.Move) bool { return a(pos, last) && b(pos, last) }
+}
+
+func or(a, b predicate) predicate {
+	return func(pos *chess.Position, last *chess.Move) bool { return a(pos, last) || b(pos, last) }
+}
+
+func not(a predicate) predicate {
+	return func(pos *chess.Position, last *chess.Move) bool { return !a(pos, last) }
+}
+
+// A pieceSet is a set of pieces, including chess.NoPiece for empty squares,
+// with bit p set for each piece p.
+type pieceSet uint16
+
+func (s pieceSet) has(p chess.Piece) bool {
+	return s&(1<<p) != 0
+}
+
+// A squareSet is a set of squares with bit sq set for each square sq.
+type squareSet uint64
+
+const allSquares squareSet = 1<<64 - 1
+
+func (s squareSet) has(sq chess.Square) bool {
+	return s&(1<<sq) != 0
+}
+
+//nolint:gochecknoglobals // lookup table.
+var pieceSets = map[rune]pieceSet{
+	'K': 1 << chess.WhiteKing,
+	'Q': 1 << chess.WhiteQueen,
+	'R': 1 << chess.WhiteRook,
+	'B': 1 << chess.WhiteBishop,
+	'N': 1 << chess.WhiteKnight,
+	'P': 1 << chess.WhitePawn,
+	'k': 1 << chess.BlackKing,
+	'q': 1 << chess.BlackQueen,
+	'r': 1 << chess.BlackRook,
+	'b': 1 << chess.BlackBishop,
+	'n': 1 << chess.BlackKnight,
+	'p': 1 << chess.BlackPawn,
+	'A': 1<<chess.WhiteKing | 1<<chess.WhiteQueen | 1<<chess.WhiteRook |
+		1<<chess.WhiteBishop | 1<<chess.WhiteKnight | 1<<chess.WhitePawn,
+	'a': 1<<chess.BlackKing | 1<<chess.BlackQueen | 1<<chess.BlackRook |
+		1<<chess.BlackBishop | 1<<chess.BlackKnight | 1<<chess.BlackPawn,
+	'_': 1 << chess.NoPiece,
+}
+
+// A designator designates the squares of a set holding a piece of a set.
+type designator struct {
+	pieces  pieceSet
+	squares squareSet
+}
+
+// squaresOf returns the designated squares of pos.
+func (d designator) squaresOf(pos *chess.Position) squareSet {
+	var set squareSet
+	board := pos.Board()
+	for sq := chess.A1; sq <= chess.H8; sq++ {
+		if d.squares.has(sq) && d.pieces.has(board.Piece(sq)) {
+			set |= 1 << sq
+		}
+	}
+	return set
+}
+
+func (d designator) present(pos *chess.Position, _ *chess.Move) bool {
+	return d.squaresOf(pos) != 0
+}
+
+func attacks(attackers, targets designator) predicate {
+	return func(pos *chess.Position, _ *chess.Move) bool {
+		from := attackers.squaresOf(pos)
+		if from == 0 {
+			return false
+		}
+		to := targets.squaresOf(pos)
+		board := pos.Board()
+		for sq := chess.A1; sq <= chess.H8; sq++ {
+			if !from.has(sq) {
+				continue
+			}
+			for _, target := range board.Attacks(sq) {
+				if to.has(target) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func lastMove(d designator) predicate {
+	return func(pos *chess.Position, last *chess.Move) bool {
+		return last != nil && d.squares.has(last.S2()) && d.pieces.has(pos.Board().Piece(last.S2()))
+	}
+}
+
+func isCapture(_ *chess.Position, last *chess.Move) bool {
+	return last != nil && (last.HasTag(chess.Capture) || last.HasTag(chess.EnPassant))
+}
+
+func isCheck(pos *chess.Position, _ *chess.Move) bool {
+	return pos.InCheck()
+}
+
+func isMate(pos *chess.Position, _ *chess.Move) bool {
+	return pos.Status() == chess.Checkmate
+}
+
+func isStalemate(pos *chess.Position, _ *chess.Move) bool {
+	return pos.Status() == chess.Stalemate
+}
+
+func toMove(c chess.Color) predicate {
+	return func(pos *chess.Position, _ *chess.Move) bool { return pos.Turn() == c }
+}
+
+func moveNumberIs(cmp func(a, b int) bool, n int) predicate {
+	return func(pos *chess.Position, _ *chess.Move) bool { return cmp((pos.Ply()+1)/2, n) }
+}
+
+func plyIs(cmp func(a, b int) bool, n int) predicate {
+	return func(pos *chess.Position, _ *chess.Move) bool { return cmp(pos.Ply()-1, n) }
+}
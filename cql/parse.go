@@ -0,0 +1,296 @@
+package cql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/corentings/chess/v2"
+)
+
+// SyntaxError is returned by Compile when a query is malformed.
+type SyntaxError struct {
+	Msg    string // Description of the error
+	Token  string // Offending token, empty at the end of the query
+	Offset int    // Byte offset of the offending token in the query
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("cql: %s at end of query", e.Msg)
+	}
+	return fmt.Sprintf("cql: %s at offset %d (%q)", e.Msg, e.Offset, e.Token)
+}
+
+type token struct {
+	value  string
+	offset int
+}
+
+// tokenize splits the query into words and parentheses.
+func tokenize(src string) []token {
+	var toks []token
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			toks = append(toks, token{value: src[start:end], offset: start})
+			start = -1
+		}
+	}
+	for i, r := range src {
+		switch {
+		case unicode.IsSpace(r):
+			flush(i)
+		case r == '(' || r == ')':
+			flush(i)
+			toks = append(toks, token{value: string(r), offset: i})
+		case start < 0:
+			start = i
+		}
+	}
+	flush(len(src))
+	return toks
+}
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.toks) {
+		return token{offset: -1}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return &SyntaxError{Msg: fmt.Sprintf(format, args...), Token: tok.value, Offset: tok.offset}
+}
+
+// parseOr parses: and { "or" and }.
+func (p *parser) parseOr() (predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().value == "or" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = or(left, right)
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary { ["and"] unary }. Juxtaposed filters are and-ed.
+func (p *parser) parseAnd() (predicate, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().value {
+		case "and":
+			p.next()
+		case "", "or", ")":
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = and(left, right)
+	}
+}
+
+// parseUnary parses: "not" unary | "(" or ")" | filter.
+func (p *parser) parseUnary() (predicate, error) {
+	tok := p.next()
+	switch tok.value {
+	case "":
+		return nil, p.errorf(tok, "expected a filter")
+	case "not":
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return not(inner), nil
+	case "(":
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if end := p.next(); end.value != ")" {
+			return nil, p.errorf(end, "expected )")
+		}
+		return inner, nil
+	case "check":
+		return isCheck, nil
+	case "mate":
+		return isMate, nil
+	case "stalemate":
+		return isStalemate, nil
+	case "wtm":
+		return toMove(chess.White), nil
+	case "btm":
+		return toMove(chess.Black), nil
+	case "capture":
+		return isCapture, nil
+	case "attacks":
+		attackers, err := p.parseDesignator(p.next())
+		if err != nil {
+			return nil, err
+		}
+		targets, err := p.parseDesignator(p.next())
+		if err != nil {
+			return nil, err
+		}
+		return attacks(attackers, targets), nil
+	case "lastmove":
+		d, err := p.parseDesignator(p.next())
+		if err != nil {
+			return nil, err
+		}
+		return lastMove(d), nil
+	case "movenumber", "ply":
+		cmp, n, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		if tok.value == "ply" {
+			return plyIs(cmp, n), nil
+		}
+		return moveNumberIs(cmp, n), nil
+	default:
+		d, err := p.parseDesignator(tok)
+		if err != nil {
+			return nil, err
+		}
+		return d.present, nil
+	}
+}
+
+// parseComparison parses: operator number.
+func (p *parser) parseComparison() (func(a, b int) bool, int, error) {
+	op := p.next()
+	var cmp func(a, b int) bool
+	switch op.value {
+	case "<":
+		cmp = func(a, b int) bool { return a < b }
+	case "<=":
+		cmp = func(a, b int) bool { return a <= b }
+	case ">":
+		cmp = func(a, b int) bool { return a > b }
+	case ">=":
+		cmp = func(a, b int) bool { return a >= b }
+	case "==", "=":
+		cmp = func(a, b int) bool { return a == b }
+	case "!=":
+		cmp = func(a, b int) bool { return a != b }
+	default:
+		return nil, 0, p.errorf(op, "expected a comparison operator")
+	}
+	num := p.next()
+	n, err := strconv.Atoi(num.value)
+	if err != nil {
+		return nil, 0, p.errorf(num, "expected a number")
+	}
+	return cmp, n, nil
+}
+
+// parseDesignator parses a piece designator: a piece set optionally
+// followed by a square set, e.g. "Q", "Qa-h7", "[Rr]e1-8" or "_[e4,d5]".
+func (p *parser) parseDesignator(tok token) (designator, error) {
+	s := tok.value
+	var d designator
+	if s == "" {
+		return d, p.errorf(tok, "expected a piece designator")
+	}
+
+	var letters string
+	if s[0] == '[' {
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return d, p.errorf(tok, "unterminated piece set")
+		}
+		letters, s = s[1:end], s[end+1:]
+	} else {
+		letters, s = s[:1], s[1:]
+	}
+	if letters == "" {
+		return d, p.errorf(tok, "empty piece set")
+	}
+	for _, r := range letters {
+		set, ok := pieceSets[r]
+		if !ok {
+			return d, p.errorf(tok, "invalid piece %q", r)
+		}
+		d.pieces |= set
+	}
+
+	if s == "" {
+		d.squares = allSquares
+		return d, nil
+	}
+	parts := []string{s}
+	if s[0] == '[' {
+		if s[len(s)-1] != ']' {
+			return d, p.errorf(tok, "unterminated square set")
+		}
+		parts = strings.Split(s[1:len(s)-1], ",")
+	}
+	for _, part := range parts {
+		squares, ok := parseSquares(part)
+		if !ok {
+			return d, p.errorf(tok, "invalid squares %q", part)
+		}
+		d.squares |= squares
+	}
+	return d, nil
+}
+
+// parseSquares parses a square or a range of squares such as "e4",
+// "a-h7", "e1-8" or "a-d1-4".
+func parseSquares(s string) (squareSet, bool) {
+	fromFile, toFile, s, ok := parseRange(s, 'a', 'h')
+	if !ok {
+		return 0, false
+	}
+	fromRank, toRank, s, ok := parseRange(s, '1', '8')
+	if !ok || s != "" {
+		return 0, false
+	}
+	var set squareSet
+	for f := fromFile; f <= toFile; f++ {
+		for r := fromRank; r <= toRank; r++ {
+			set |= 1 << chess.NewSquare(chess.File(f), chess.Rank(r))
+		}
+	}
+	return set, true
+}
+
+// parseRange parses "x" or "x-y" at the start of s, with x and y in
+// [lo, hi], and returns them relative to lo along with the rest of s.
+func parseRange(s string, lo, hi byte) (int, int, string, bool) {
+	in := func(i int) bool { return i < len(s) && s[i] >= lo && s[i] <= hi }
+	if !in(0) {
+		return 0, 0, s, false
+	}
+	from, to := int(s[0]-lo), int(s[0]-lo)
+	s = s[1:]
+	if len(s) >= 2 && s[0] == '-' && in(1) {
+		to = int(s[1] - lo)
+		s = s[2:]
+	}
+	return from, to, s, from <= to
+}
@@ -0,0 +1,158 @@
+// Package cql searches positions with a small query language modeled on a
+// subset of CQL, the Chess Query Language. A query is compiled once and
+// then matched against every position of a game or of a PGN stream.
+//
+// Example:
+//
+//	q := cql.MustCompile("mate lastmove N")
+//	err := q.Scan(chess.NewScanner(r), func(m cql.Match) error {
+//	    if m.Err != nil {
+//	        return nil // skip games that can't be parsed
+//	    }
+//	    fmt.Println(m.Game.GetTagPair("White"), m.Move)
+//	    return nil
+//	})
+//
+// # Syntax
+//
+// A query is a sequence of filters. Filters written next to each other must
+// all match; "and", "or", "not" and parentheses combine them explicitly,
+// with "not" binding tightest and "or" loosest.
+//
+// A piece designator matches if any square it designates holds one of its
+// pieces. It is a piece set optionally followed by a square set:
+//
+//	K Q R B N P     a white piece
+//	k q r b n p     a black piece
+//	A a             any white piece, any black piece
+//	_               an empty square
+//	[Qq]            any of the listed pieces
+//	e4              a square
+//	a-h7 e1-8       a rank, a file, or any rectangle like c-f3-6
+//	[a7,c1-8]       any of the listed squares or ranges
+//
+// The other filters are:
+//
+//	attacks D1 D2   a piece of D1 attacks a square of D2
+//	lastmove D      the last move ended on a square of D with one of its pieces
+//	capture         the last move was a capture
+//	check           the side to move is in check
+//	mate            the side to move is checkmated
+//	stalemate       the side to move is stalemated
+//	wtm btm         white to move, black to move
+//	movenumber OP N the full move number, as in FEN, compares to N
+//	ply OP N        the number of half-moves played compares to N
+//
+// where OP is one of <, <=, >, >=, == and !=.
+//
+// Examples:
+//
+//	Qa-h7                  a white queen on the 7th rank
+//	attacks N q            a white knight attacks a black queen
+//	mate lastmove N        mate delivered by a white knight
+//	Pe6 kg8                a white pawn on e6 and the black king on g8
+//	movenumber > 30 not Q  past move 30 without a white queen
+package cql
+
+import (
+	"errors"
+	"io"
+
+	"github.com/corentings/chess/v2"
+)
+
+// A Query is a compiled query. It is safe for concurrent use.
+type Query struct {
+	src   string
+	match predicate
+}
+
+// Compile parses a query. Malformed queries are reported with a
+// *SyntaxError.
+func Compile(src string) (*Query, error) {
+	p := &parser{toks: tokenize(src)}
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.value != "" {
+		return nil, p.errorf(tok, "unexpected token")
+	}
+	return &Query{src: src, match: match}, nil
+}
+
+// MustCompile is like Compile but panics if the query is malformed.
+func MustCompile(src string) *Query {
+	q, err := Compile(src)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// String returns the source of the query.
+func (q *Query) String() string {
+	return q.src
+}
+
+// Match reports whether pos matches the query. last is the move that led to
+// pos, or nil for a starting position; filters on the last move don't match
+// without one.
+func (q *Query) Match(pos *chess.Position, last *chess.Move) bool {
+	return q.match(pos, last)
+}
+
+// A Match is a position matching a query.
+type Match struct {
+	Game     *chess.Game
+	Move     *chess.Move     // Move that led to Position, nil for the starting position
+	Position *chess.Position // Matching position
+	Index    int             // Index of the game in the scanned input
+	Err      error           // Error parsing the game, if any
+}
+
+// Find returns the positions of the main line of g, including the starting
+// position, that match the query.
+func (q *Query) Find(g *chess.Game) []Match {
+	var found []Match
+	positions := g.Positions()
+	moves := g.Moves()
+	for i, pos := range positions {
+		var last *chess.Move
+		if i > 0 && i <= len(moves) {
+			last = moves[i-1]
+		}
+		if q.match(pos, last) {
+			found = append(found, Match{Game: g, Move: last, Position: pos})
+		}
+	}
+	return found
+}
+
+// Scan reads the remaining games of the scanner and calls fn with each
+// matching position. Games that can't be parsed are passed to fn with their
+// error. Scan stops at the first error returned by fn or by the scanner.
+func (q *Query) Scan(s *chess.Scanner, fn func(Match) error) error {
+	for {
+		scanned, err := s.ScanGame()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		game, err := s.Parse(scanned)
+		if err != nil {
+			if err := fn(Match{Index: scanned.Index, Err: err}); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, m := range q.Find(game) {
+			m.Index = scanned.Index
+			if err := fn(m); err != nil {
+				return err
+			}
+		}
+	}
+}
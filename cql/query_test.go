@@ -0,0 +1,157 @@
+package cql_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/corentings/chess/v2"
+	"github.com/corentings/chess/v2/cql"
+)
+
+const testPGN = `[Event "Legal's mate"]
+[Result "1-0"]
+
+1. e4 e5 2. Nf3 d6 3. Bc4 Bg4 4. Nc3 g6 5. Nxe5 Bxd1 6. Bxf7+ Ke7 7. Nd5# 1-0
+
+[Event "Scholar's mate"]
+[Result "1-0"]
+
+1. e4 e5 2. Qh5 Nc6 3. Bc4 Nf6 4. Qxf7# 1-0
+
+[Event "Broken"]
+[Result "*"]
+
+1. e4 e5 2. Ke3 *
+`
+
+func position(t *testing.T, fen string) *chess.Position {
+	t.Helper()
+	var pos chess.Position
+	if err := pos.UnmarshalText([]byte(fen)); err != nil {
+		t.Fatalf("invalid FEN %q: %v", fen, err)
+	}
+	return &pos
+}
+
+func TestQueryMatch(t *testing.T) {
+	tests := []struct {
+		query    string
+		fen      string
+		expected bool
+	}{
+		{"Qa-h7", "4k3/3Q4/8/8/8/8/8/4K3 b - - 0 1", true},
+		{"Qa-h7", "4k3/8/3Q4/8/8/8/8/4K3 b - - 0 1", false},
+		{"Qa-h7", "4k3/3q4/8/8/8/8/8/4K3 w - - 0 1", false},
+		{"Pe6 kg8", "6k1/8/4P3/8/8/8/8/4K3 w - - 0 1", true},
+		{"Pe6 and kg8", "7k/8/4P3/8/8/8/8/4K3 w - - 0 1", false},
+		{"Pe6 or kg8", "7k/8/4P3/8/8/8/8/4K3 w - - 0 1", true},
+		{"attacks N q", "4k3/8/3q4/8/4N3/8/8/4K3 b - - 0 1", true},
+		{"attacks N q", "4k3/8/4q3/8/4N3/8/8/4K3 b - - 0 1", false},
+		{"attacks R k", "4k3/8/8/8/4P3/8/8/4RK2 b - - 0 1", false},
+		{"attacks R k", "4k3/8/8/8/8/8/8/4RK2 b - - 0 1", true},
+		{"attacks [Qq] [Aa]", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", false},
+		{"attacks p Pd4", "4k3/8/8/2p5/3P4/8/8/4K3 w - - 0 1", true},
+		{"attacks A _e5", "4k3/8/8/8/3P4/8/8/4K3 w - - 0 1", true},
+		{"check", "4k3/8/8/8/8/8/8/4RK2 b - - 0 1", true},
+		{"check", "4k3/8/8/8/8/8/8/4RK2 w - - 0 1", false},
+		{"mate", "R5k1/5ppp/8/8/8/8/8/6K1 b - - 0 1", true},
+		{"stalemate", "7k/5Q2/6K1/8/8/8/8/8 b - - 0 1", true},
+		{"wtm", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", true},
+		{"btm", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", false},
+		{"movenumber > 30", "4k3/8/8/8/8/8/8/4K3 w - - 0 31", true},
+		{"movenumber > 30", "4k3/8/8/8/8/8/8/4K3 b - - 0 30", false},
+		{"ply == 61", "4k3/8/8/8/8/8/8/4K3 b - - 0 31", true},
+		{"not ([Rr] or _e1)", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", true},
+		{"_[e1,a-d8]", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", true},
+		{"lastmove A", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q := cql.MustCompile(tt.query)
+			if got := q.Match(position(t, tt.fen), nil); got != tt.expected {
+				t.Errorf("%q on %q: expected %v, got %v", tt.query, tt.fen, tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestQueryScan(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string // Game index and move of each match
+	}{
+		{"mate lastmove N", []string{"0 c3d5"}},
+		{"mate", []string{"0 c3d5", "1 h5f7"}},
+		{"Qa-h7", []string{"1 h5f7"}},
+		{"capture lastmove Q", []string{"1 h5f7"}},
+		{"check btm movenumber >= 6", []string{"0 c4f7", "0 c3d5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q := cql.MustCompile(tt.query)
+			var got []string
+			errs := 0
+			err := q.Scan(chess.NewScanner(strings.NewReader(testPGN)), func(m cql.Match) error {
+				if m.Err != nil {
+					errs++
+					return nil
+				}
+				got = append(got, fmt.Sprintf("%d %s", m.Index, m.Move))
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if errs != 1 {
+				t.Errorf("expected the broken game to be reported once, got %d", errs)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestQueryFindStartingPosition(t *testing.T) {
+	found := cql.MustCompile("wtm Pe2").Find(chess.NewGame())
+	if len(found) != 1 || found[0].Move != nil {
+		t.Fatalf("expected the starting position to match, got %v", found)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		query  string
+		offset int
+	}{
+		{"", -1},
+		{"Xe4", 0},
+		{"Qi7", 0},
+		{"Qh9", 0},
+		{"Q[a7", 0},
+		{"check and", -1},
+		{"(mate", -1},
+		{"mate )", 5},
+		{"attacks N", -1},
+		{"movenumber 30", 11},
+		{"movenumber > x", 13},
+		{"Qa7 Qh-a7", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := cql.Compile(tt.query)
+			var serr *cql.SyntaxError
+			if !errors.As(err, &serr) {
+				t.Fatalf("expected a syntax error, got %v", err)
+			}
+			if serr.Offset != tt.offset {
+				t.Errorf("expected offset %d, got %d (%v)", tt.offset, serr.Offset, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package chess
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// An Encoding is the character encoding of a PGN source. The PGN standard
+// mandates Latin-1, but most modern files are UTF-8 and many historical ones
+// were written with Windows-1252.
+type Encoding uint8
+
+const (
+	// EncodingUTF8 reads the source as UTF-8 without converting it. This
+	// is the default. Invalid sequences are kept as is, or replaced with
+	// a warning in lenient mode.
+	EncodingUTF8 Encoding = iota
+	// EncodingLatin1 decodes the source from ISO-8859-1.
+	EncodingLatin1
+	// EncodingWindows1252 decodes the source from Windows-1252 (CP1252),
+	// a superset of the printable characters of Latin-1.
+	EncodingWindows1252
+	// EncodingAuto keeps games that are valid UTF-8 and decodes the others
+	// from Windows-1252. Detection is done for each game, so files mixing
+	// both encodings are read correctly.
+	EncodingAuto
+)
+
+// String returns the name of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case EncodingUTF8:
+		return "UTF-8"
+	case EncodingLatin1:
+		return "ISO-8859-1"
+	case EncodingWindows1252:
+		return "Windows-1252"
+	case EncodingAuto:
+		return "auto"
+	}
+	return "unknown"
+}
+
+// utf8BOM is the byte order mark some editors write at the start of UTF-8
+// files.
+const utf8BOM = "\xEF\xBB\xBF"
+
+// windows1252 maps the bytes 0x80 to 0x9F of Windows-1252 to runes. Bytes
+// that are undefined in Windows-1252 map to the C1 control of the same value.
+//
+//nolint:gochecknoglobals // lookup table.
+var windows1252 = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '\u008D', 'Ž', '\u008F',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '\u009D', 'ž', 'Ÿ',
+}
+
+// decode converts text in the encoding to valid UTF-8. Text in EncodingUTF8
+// is returned unchanged.
+func (e Encoding) decode(text []byte) string {
+	switch e {
+	case EncodingLatin1, EncodingWindows1252:
+	case EncodingAuto:
+		if utf8.Valid(text) {
+			return string(text)
+		}
+		e = EncodingWindows1252
+	default:
+		return string(text)
+	}
+
+	ascii := true
+	for _, c := range text {
+		if c >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return string(text)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(text) + len(text)/8)
+	for _, c := range text {
+		switch {
+		case c < utf8.RuneSelf:
+			sb.WriteByte(c)
+		case e == EncodingWindows1252 && c < 0xA0:
+			sb.WriteRune(windows1252[c-0x80])
+		default:
+			sb.WriteRune(rune(c))
+		}
+	}
+	return sb.String()
+}
@@ -167,6 +167,39 @@ func standardMoves(pos *Position, first bool) []Move {
 	return result
 }
 
+// pseudoLegalMoves returns the standard moves of the side to move,
+// including those leaving its king in check, which are tagged inCheck.
+// Castling moves are not included.
+func pseudoLegalMoves(pos *Position) []Move {
+	var moves []Move
+	bbAllowed := ^pos.board.whiteSqs
+	if pos.Turn() == Black {
+		bbAllowed = ^pos.board.blackSqs
+	}
+	for s1 := range numOfSquaresInBoard {
+		p := pos.board.Piece(Square(s1))
+		if p == NoPiece || p.Color() != pos.Turn() {
+			continue
+		}
+		s2BB := bbForPossibleMoves(pos, p.Type(), Square(s1)) & bbAllowed
+		for s2 := range numOfSquaresInBoard {
+			if s2BB&bbForSquare(Square(s2)) == 0 {
+				continue
+			}
+			promos := []PieceType{NoPieceType}
+			if p.Type() == Pawn && (Square(s2).Rank() == Rank8 || Square(s2).Rank() == Rank1) {
+				promos = promoPieceTypes
+			}
+			for _, pt := range promos {
+				m := Move{s1: Square(s1), s2: Square(s2), promo: pt}
+				addTags(&m, pos)
+				moves = append(moves, m)
+			}
+		}
+	}
+	return moves
+}
+
 // addTags updates a move's tags based on the resulting position.
 // Tags include:
 //   - Capture: The move captures an opponent's piece
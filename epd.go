@@ -0,0 +1,326 @@
+package chess
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Common EPD opcodes. Comments are stored under the opcodes c0 to c9.
+const (
+	EPDAnalysisCountDepth  = "acd"
+	EPDAvoidMove           = "am"
+	EPDBestMove            = "bm"
+	EPDCentipawnEvaluation = "ce"
+	EPDDirectMate          = "dm"
+	EPDFullMoveNumber      = "fmvn"
+	EPDHalfMoveClock       = "hmvc"
+	EPDID                  = "id"
+	EPDPredictedMove       = "pm"
+	EPDPredictedVariation  = "pv"
+	EPDSuppliedMove        = "sm"
+)
+
+// epdMoveOpcodes are the opcodes whose operands are moves in SAN. The moves
+// of a predicted variation are played in sequence, the others all apply to
+// the position of the record.
+//
+//nolint:gochecknoglobals // lookup table.
+var epdMoveOpcodes = []string{EPDAvoidMove, EPDBestMove, EPDPredictedMove, EPDPredictedVariation, EPDSuppliedMove}
+
+// epdStringOpcodes are the opcodes whose operand is a string, always
+// written between quotes.
+//
+//nolint:gochecknoglobals // lookup table.
+var epdStringOpcodes = []string{
+	EPDID, "c0", "c1", "c2", "c3", "c4", "c5", "c6", "c7", "c8", "c9",
+	"eco", "nic", "tcgs", "tcri", "tcsi", "v0", "v1", "v2", "v3", "v4", "v5", "v6", "v7", "v8", "v9",
+}
+
+// EPD is a record in Extended Position Description: the first four fields
+// of a FEN followed by operations, e.g.
+//
+//	r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - bm Bb5; id "Ruy Lopez";
+//
+// The clocks of the position are taken from the hmvc and fmvn operations,
+// zero and one when they are missing.
+type EPD struct {
+	Position *Position
+	// Ops maps each opcode to its operands.
+	Ops map[string][]EPDOperand
+}
+
+// EPDOperand is an operand of an EPD operation.
+type EPDOperand struct {
+	Value string // The operand as written, without quotes
+	Move  *Move  // The move of a move operand (am, bm, pm, pv, sm), nil otherwise
+}
+
+// ParseEPD parses a single EPD record. The move operands are decoded from
+// SAN and must be legal.
+func ParseEPD(s string) (*EPD, error) {
+	fields, rest, err := splitEPDFields(s)
+	if err != nil {
+		return nil, err
+	}
+	var pos Position
+	if err := pos.UnmarshalText([]byte(strings.Join(fields, " ") + " 0 1")); err != nil {
+		return nil, err
+	}
+	e := &EPD{Position: &pos, Ops: make(map[string][]EPDOperand)}
+	if err := e.parseOps(rest); err != nil {
+		return nil, err
+	}
+
+	if n, ok := e.Int(EPDHalfMoveClock); ok {
+		if n < 0 {
+			return nil, errors.New("chess: epd invalid half move clock")
+		}
+		pos.halfMoveClock = n
+	}
+	if n, ok := e.Int(EPDFullMoveNumber); ok {
+		if n < 1 {
+			return nil, errors.New("chess: epd invalid full move number")
+		}
+		pos.moveCount = n
+	}
+	for _, opcode := range epdMoveOpcodes {
+		if err := e.decodeMoves(opcode); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// splitEPDFields splits the four position fields of an EPD record from the
+// operations.
+func splitEPDFields(s string) ([]string, string, error) {
+	const positionFields = 4
+	fields := make([]string, 0, positionFields)
+	rest := strings.TrimSpace(s)
+	for range positionFields {
+		field, after, _ := strings.Cut(rest, " ")
+		if field == "" {
+			return nil, "", errors.New("chess: epd invalid format")
+		}
+		fields = append(fields, field)
+		rest = strings.TrimLeft(after, " \t")
+	}
+	return fields, rest, nil
+}
+
+// parseOps parses the operations of a record. The semicolon ending the
+// last operation may be omitted.
+func (e *EPD) parseOps(s string) error {
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return nil
+		}
+		end := strings.IndexAny(s, " \t;")
+		if end < 0 {
+			end = len(s)
+		}
+		opcode := s[:end]
+		if !isLetter(opcode[0]) || strings.ContainsFunc(opcode, func(r rune) bool {
+			return r >= 0x80 || !isAlphaNumeric(byte(r)) && r != '_'
+		}) {
+			return fmt.Errorf("chess: epd invalid opcode %q", opcode)
+		}
+		if _, ok := e.Ops[opcode]; ok {
+			return fmt.Errorf("chess: epd duplicate opcode %q", opcode)
+		}
+		s = s[end:]
+
+		operands := []EPDOperand{}
+		for {
+			s = strings.TrimLeft(s, " \t")
+			if s == "" || s[0] == ';' {
+				break
+			}
+			if s[0] == '"' {
+				end := strings.IndexByte(s[1:], '"')
+				if end < 0 {
+					return fmt.Errorf("chess: epd unterminated string in %q", opcode)
+				}
+				operands = append(operands, EPDOperand{Value: s[1 : end+1]})
+				s = s[end+2:]
+				continue
+			}
+			end := strings.IndexAny(s, " \t;")
+			if end < 0 {
+				end = len(s)
+			}
+			operands = append(operands, EPDOperand{Value: s[:end]})
+			s = s[end:]
+		}
+		e.Ops[opcode] = operands
+		s = strings.TrimPrefix(s, ";")
+	}
+}
+
+// decodeMoves decodes the move operands of the opcode.
+func (e *EPD) decodeMoves(opcode string) error {
+	operands := e.Ops[opcode]
+	pos := e.Position
+	for i := range operands {
+		m, err := AlgebraicNotation{Lenient: true}.Decode(pos, operands[i].Value)
+		if err != nil {
+			return fmt.Errorf("chess: epd %s: %w", opcode, err)
+		}
+		operands[i].Move = m
+		if opcode == EPDPredictedVariation {
+			pos = pos.Update(m)
+		}
+	}
+	return nil
+}
+
+// Moves returns the moves of a move operation, e.g. the best moves, or nil
+// when the operation is missing.
+func (e *EPD) Moves(opcode string) []*Move {
+	var moves []*Move
+	for _, op := range e.Ops[opcode] {
+		if op.Move != nil {
+			moves = append(moves, op.Move)
+		}
+	}
+	return moves
+}
+
+// Text returns the first operand of the opcode, e.g. the id of the record,
+// or an empty string when the operation is missing.
+func (e *EPD) Text(opcode string) string {
+	if ops := e.Ops[opcode]; len(ops) > 0 {
+		return ops[0].Value
+	}
+	return ""
+}
+
+// Int returns the first operand of the opcode as an integer, e.g. the
+// centipawn evaluation. It reports false when the operation is missing or
+// its operand is not an integer.
+func (e *EPD) Int(opcode string) (int, bool) {
+	ops := e.Ops[opcode]
+	if len(ops) == 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(ops[0].Value)
+	return n, err == nil
+}
+
+// SetMoves sets the moves of a move operation. The moves of a predicted
+// variation are played in sequence from the position of the record.
+func (e *EPD) SetMoves(opcode string, moves ...*Move) {
+	pos := e.Position
+	operands := make([]EPDOperand, len(moves))
+	for i, m := range moves {
+		operands[i] = EPDOperand{Value: AlgebraicNotation{}.Encode(pos, m), Move: m}
+		if opcode == EPDPredictedVariation {
+			pos = pos.Update(m)
+		}
+	}
+	e.set(opcode, operands)
+}
+
+// SetText sets the single operand of the opcode.
+func (e *EPD) SetText(opcode, s string) {
+	e.set(opcode, []EPDOperand{{Value: s}})
+}
+
+// SetInt sets the single integer operand of the opcode.
+func (e *EPD) SetInt(opcode string, n int) {
+	e.SetText(opcode, strconv.Itoa(n))
+}
+
+func (e *EPD) set(opcode string, operands []EPDOperand) {
+	if e.Ops == nil {
+		e.Ops = make(map[string][]EPDOperand)
+	}
+	e.Ops[opcode] = operands
+}
+
+// String returns the canonical form of the record: the position fields
+// followed by the operations in the ASCII order of their opcodes, each
+// ended by a semicolon. Moves are written in SAN and string operands
+// between quotes.
+func (e *EPD) String() string {
+	fields := strings.Fields(e.Position.String())
+	var sb strings.Builder
+	sb.WriteString(strings.Join(fields[:4], " "))
+
+	opcodes := make([]string, 0, len(e.Ops))
+	for opcode := range e.Ops {
+		opcodes = append(opcodes, opcode)
+	}
+	slices.Sort(opcodes)
+	for _, opcode := range opcodes {
+		sb.WriteByte(' ')
+		sb.WriteString(opcode)
+		quoted := slices.Contains(epdStringOpcodes, opcode)
+		pos := e.Position
+		for _, op := range e.Ops[opcode] {
+			sb.WriteByte(' ')
+			switch {
+			case op.Move != nil:
+				sb.WriteString(AlgebraicNotation{}.Encode(pos, op.Move))
+				if opcode == EPDPredictedVariation {
+					pos = pos.Update(op.Move)
+				}
+			case quoted || op.Value == "" || strings.ContainsAny(op.Value, " \t;"):
+				sb.WriteString(`"` + op.Value + `"`)
+			default:
+				sb.WriteString(op.Value)
+			}
+		}
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
+
+// EPDReader reads EPD records, one per line. Blank lines are skipped.
+type EPDReader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+// NewEPDReader returns a reader of EPD records from r.
+func NewEPDReader(r io.Reader) *EPDReader {
+	return &EPDReader{s: bufio.NewScanner(r)}
+}
+
+// Read reads and parses the next record. It returns nil and io.EOF when no
+// more records are available. A record that can't be parsed is reported
+// with its line number and reading can go on with the next one.
+//
+// Example:
+//
+//	for {
+//	    epd, err := reader.Read()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    // Process record
+//	}
+func (r *EPDReader) Read() (*EPD, error) {
+	for r.s.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.s.Text())
+		if text == "" {
+			continue
+		}
+		e, err := ParseEPD(text)
+		if err != nil {
+			return nil, fmt.Errorf("chess: epd line %d: %w", r.line, err)
+		}
+		return e, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
@@ -0,0 +1,106 @@
+package chess
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseEPD(t *testing.T) {
+	e, err := ParseEPD(`2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - bm Qg6; id "WAC.001"; ` +
+		`c0 "mate; in 3"; ce +32000;acd 12; pv Qg6 fxg6 Nxg6#; hmvc 3; fmvn 20`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := e.Position.String(); got != "2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - 3 20" {
+		t.Errorf("unexpected position %s", got)
+	}
+	if bm := e.Moves(EPDBestMove); len(bm) != 1 || bm[0].String() != "g3g6" {
+		t.Errorf("expected best move g3g6, got %v", bm)
+	}
+	if pv := e.Moves(EPDPredictedVariation); len(pv) != 3 || pv[2].String() != "e5g6" {
+		t.Errorf("expected the predicted variation to end with e5g6, got %v", pv)
+	}
+	if id := e.Text(EPDID); id != "WAC.001" {
+		t.Errorf("expected id WAC.001, got %q", id)
+	}
+	if c0 := e.Text("c0"); c0 != "mate; in 3" {
+		t.Errorf("expected comment %q, got %q", "mate; in 3", c0)
+	}
+	if ce, ok := e.Int(EPDCentipawnEvaluation); !ok || ce != 32000 {
+		t.Errorf("expected evaluation 32000, got %d %v", ce, ok)
+	}
+	if _, ok := e.Int(EPDDirectMate); ok {
+		t.Error("expected no direct mate operation")
+	}
+
+	expected := `2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - acd 12; bm Qg6; c0 "mate; in 3"; ` +
+		`ce +32000; fmvn 20; hmvc 3; id "WAC.001"; pv Qg6 fxg6 Nxg6#;`
+	if got := e.String(); got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+	again, err := ParseEPD(e.String())
+	if err != nil || again.String() != expected {
+		t.Errorf("expected the canonical form to round-trip, got %v %v", again, err)
+	}
+}
+
+func TestParseEPDErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"8/8/8/8/8/8/8/8 w -",
+		"4k3/8/8/8/8/8/8/4K3 x - - id \"a\";",
+		"4k3/8/8/8/8/8/8/4K3 w - - id \"a;",
+		"4k3/8/8/8/8/8/8/4K3 w - - 0 1",
+		"4k3/8/8/8/8/8/8/4K3 w - - id a; id b;",
+		"4k3/8/8/8/8/8/8/4K3 w - - bm Qd1;",
+		"4k3/8/8/8/8/8/8/4K3 w - - hmvc -1;",
+		"4k3/8/8/8/8/8/8/4K3 w - - fmvn 0;",
+	}
+	for _, s := range tests {
+		if _, err := ParseEPD(s); err == nil {
+			t.Errorf("expected %q to be invalid", s)
+		}
+	}
+}
+
+func TestEPDSet(t *testing.T) {
+	e := &EPD{Position: StartingPosition()}
+	e.SetMoves(EPDBestMove, &e.Position.ValidMoves()[0])
+	e.SetText(EPDID, "start")
+	e.SetInt(EPDAnalysisCountDepth, 20)
+	expected := `rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - acd 20; bm ` +
+		AlgebraicNotation{}.Encode(e.Position, &e.Position.ValidMoves()[0]) + `; id "start";`
+	if got := e.String(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestEPDReader(t *testing.T) {
+	input := `4k3/8/8/8/8/8/8/4K2R w K - bm O-O; id "castle";
+
+4k3/8/8/8/8/8/8/4K3 w - - bm Qd1;
+4k3/P7/8/8/8/8/8/4K3 w - - am a8=Q; id "promotion";
+`
+	r := NewEPDReader(strings.NewReader(input))
+	var ids []string
+	var errs []error
+	for {
+		e, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, e.Text(EPDID))
+	}
+	if strings.Join(ids, ",") != "castle,promotion" {
+		t.Errorf("expected ids castle,promotion, got %v", ids)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "line 3") {
+		t.Errorf("expected an error on line 3, got %v", errs)
+	}
+}
@@ -3,6 +3,7 @@ package chess
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // PGNError custom error types for different PGN errors.
@@ -15,6 +16,11 @@ func (e *PGNError) Error() string {
 	return e.msg
 }
 
+// Offset returns the byte offset in the lexer input where the error occurred.
+func (e *PGNError) Offset() int {
+	return e.pos
+}
+
 func (e *PGNError) Is(target error) bool {
 	var t *PGNError
 	ok := errors.As(target, &t)
@@ -39,14 +45,86 @@ var (
 	ErrNoGameFound = errors.New("no game found in PGN data")
 )
 
+// ParserError is returned by Parser.Parse and Scanner.ParseNext when a game
+// can't be parsed. It locates the offending token in the PGN source and can
+// be retrieved with errors.As:
+//
+//	var perr *ParserError
+//	if errors.As(err, &perr) {
+//	    fmt.Println(perr.GameIndex, perr.Line, perr.Column)
+//	}
 type ParserError struct {
+	Err        error // Underlying error, if any
 	Message    string
 	TokenValue string
 	TokenType  TokenType
-	Position   int
+	Position   int // Index of the offending token
+	Offset     int // Byte offset of the offending token
+	Line       int // Line of the offending token, starting at 1; 0 if unknown
+	Column     int // Column of the offending token, starting at 1
+	MoveNumber int // Full move number being parsed; 0 in the header
+	GameIndex  int // Index of the game in the Scanner, starting at 0; -1 if unknown
 }
 
 func (e *ParserError) Error() string {
-	return fmt.Sprintf("Parser error at position %d: %s (Token: %v, Value: %s)",
-		e.Position, e.Message, e.TokenType, e.TokenValue)
+	var sb strings.Builder
+	sb.WriteString("Parser error")
+	if e.GameIndex >= 0 {
+		fmt.Fprintf(&sb, " in game %d", e.GameIndex)
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, " at line %d, column %d", e.Line, e.Column)
+	} else {
+		fmt.Fprintf(&sb, " at position %d", e.Position)
+	}
+	if e.MoveNumber > 0 {
+		fmt.Fprintf(&sb, " (move %d)", e.MoveNumber)
+	}
+	fmt.Fprintf(&sb, ": %s (Token: %v, Value: %s)", e.Message, e.TokenType, e.TokenValue)
+	return sb.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *ParserError) Unwrap() error {
+	return e.Err
+}
+
+// Errors wrapped by a MoveDecodeError, telling why a move couldn't be
+// decoded.
+var (
+	// ErrNoSuchMove means that no move of the position, legal or not,
+	// matches the text.
+	ErrNoSuchMove = errors.New("no such move")
+	// ErrAmbiguousMove means that several legal moves match the text.
+	ErrAmbiguousMove = errors.New("ambiguous move")
+	// ErrIllegalMove means that the move matching the text would leave
+	// the king of the moving side in check.
+	ErrIllegalMove = errors.New("illegal: leaves king in check")
+)
+
+// MoveDecodeError is returned by AlgebraicNotation.Decode when the text
+// can't be matched to a legal move. Err is ErrNoSuchMove, ErrAmbiguousMove
+// or ErrIllegalMove and can be tested with errors.Is:
+//
+//	var derr *MoveDecodeError
+//	if errors.As(err, &derr) && errors.Is(err, ErrAmbiguousMove) {
+//	    fmt.Println("did you mean", strings.Join(derr.Candidates, " or "))
+//	}
+type MoveDecodeError struct {
+	Err        error
+	Move       string   // The decoded text
+	Candidates []string // SAN of the matching legal moves of an ambiguous move
+}
+
+func (e *MoveDecodeError) Error() string {
+	msg := fmt.Sprintf("chess: move %s is not valid: %v", e.Move, e.Err)
+	if len(e.Candidates) > 0 {
+		msg += " (candidates: " + strings.Join(e.Candidates, ", ") + ")"
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *MoveDecodeError) Unwrap() error {
+	return e.Err
 }
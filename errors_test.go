@@ -23,3 +23,24 @@ func TestPGNError_Is(t *testing.T) {
 		t.Fatalf("expected errors to be different")
 	}
 }
+
+func TestParserError_Error(t *testing.T) {
+	err := &ParserError{
+		Message:    "expected tag end",
+		TokenType:  TagValue,
+		TokenValue: "x",
+		Line:       3,
+		Column:     7,
+		GameIndex:  2,
+	}
+	expected := "Parser error in game 2 at line 3, column 7: expected tag end (Token: TagValue, Value: x)"
+	if err.Error() != expected {
+		t.Fatalf("expected %s but got %s", expected, err.Error())
+	}
+
+	err = &ParserError{Message: "unterminated variation", Position: 4, GameIndex: -1}
+	expected = "Parser error at position 4: unterminated variation (Token: EOF, Value: )"
+	if err.Error() != expected {
+		t.Fatalf("expected %s but got %s", expected, err.Error())
+	}
+}
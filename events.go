@@ -0,0 +1,131 @@
+package chess
+
+// GameEventType identifies the kind of change reported to a GameListener.
+type GameEventType uint8
+
+const (
+	// MovePushedEvent is emitted after a move was added to the game, either
+	// to the main line or as a variation. Event.Move holds the new move.
+	MovePushedEvent GameEventType = iota + 1
+	// NavigationEvent is emitted after the current move changed through
+	// GoBack, GoForward or NavigateToMainLine. Event.Move holds the new
+	// current move.
+	NavigationEvent
+	// OutcomeChangedEvent is emitted whenever the outcome or the method of
+	// the game changed. Event.Outcome and Event.Method hold the new values.
+	OutcomeChangedEvent
+	// TagChangedEvent is emitted after a tag pair was added, updated or
+	// removed. Event.Key holds the tag name and Event.Value the new value
+	// (empty if the tag was removed).
+	TagChangedEvent
+	// CommentChangedEvent is emitted after the comment of a move was changed
+	// through the game. Event.Move holds the move and Event.Value the new comment.
+	CommentChangedEvent
+	// TakebackEvent is emitted after moves were taken back with Takeback.
+	// Event.Move holds the new current move.
+	TakebackEvent
+)
+
+// String implements the fmt.Stringer interface.
+func (t GameEventType) String() string {
+	switch t {
+	case MovePushedEvent:
+		return "MovePushed"
+	case NavigationEvent:
+		return "Navigation"
+	case OutcomeChangedEvent:
+		return "OutcomeChanged"
+	case TagChangedEvent:
+		return "TagChanged"
+	case CommentChangedEvent:
+		return "CommentChanged"
+	case TakebackEvent:
+		return "Takeback"
+	}
+	return "Unknown"
+}
+
+// GameEvent describes a change that happened to a Game.
+// Only the fields relevant to the event type are set.
+type GameEvent struct {
+	Move    *Move         // Move concerned by the event
+	Key     string        // Tag name for TagChangedEvent
+	Value   string        // Tag value or comment
+	Outcome Outcome       // Outcome after the event
+	Method  Method        // Method after the event
+	Type    GameEventType // Kind of event
+}
+
+// GameListener is the interface implemented by objects that want to be
+// notified of changes to a Game. Listeners are called synchronously, after
+// the change has been applied, from the goroutine that modified the game.
+type GameListener interface {
+	OnGameEvent(g *Game, e GameEvent)
+}
+
+// GameListenerFunc is an adapter to allow the use of ordinary functions
+// as game listeners.
+type GameListenerFunc func(g *Game, e GameEvent)
+
+// OnGameEvent implements the GameListener interface.
+func (f GameListenerFunc) OnGameEvent(g *Game, e GameEvent) {
+	f(g, e)
+}
+
+// WithListener returns a Game option that registers the given listener.
+// Listeners are registered in order and called in that same order.
+//
+// Example:
+//
+//	game := NewGame(WithListener(GameListenerFunc(func(g *Game, e GameEvent) {
+//	    fmt.Println(e.Type)
+//	})))
+func WithListener(l GameListener) func(*Game) {
+	return func(g *Game) {
+		g.AddListener(l)
+	}
+}
+
+// AddListener registers a listener on the game.
+func (g *Game) AddListener(l GameListener) {
+	if l == nil {
+		return
+	}
+	g.listeners = append(g.listeners, l)
+}
+
+// emit sends the event to all registered listeners.
+func (g *Game) emit(e GameEvent) {
+	for _, l := range g.listeners {
+		l.OnGameEvent(g, e)
+	}
+}
+
+// emitOutcomeChange emits an OutcomeChangedEvent if the outcome or the method
+// differs from the given previous values.
+func (g *Game) emitOutcomeChange(prevOutcome Outcome, prevMethod Method) {
+	if g.outcome == prevOutcome && g.method == prevMethod {
+		return
+	}
+	g.emit(GameEvent{Type: OutcomeChangedEvent, Outcome: g.outcome, Method: g.method})
+}
+
+// SetComment sets the comment of the given move and notifies listeners.
+// Calling Move.SetComment directly changes the comment without notification.
+func (g *Game) SetComment(m *Move, comment string) {
+	if m == nil {
+		return
+	}
+	m.SetComment(comment)
+	g.emit(GameEvent{Type: CommentChangedEvent, Move: m, Value: comment})
+}
+
+// AddComment appends to the comment of the given move and notifies listeners.
+// Calling Move.AddComment directly changes the comment without notification.
+func (g *Game) AddComment(m *Move, comment string) {
+	if m == nil {
+		return
+	}
+	m.AddComment(comment)
+	g.emit(GameEvent{Type: CommentChangedEvent, Move: m, Value: m.comments})
+}
@@ -0,0 +1,119 @@
+package chess
+
+import "testing"
+
+type recordingListener struct {
+	events []GameEvent
+}
+
+func (r *recordingListener) OnGameEvent(_ *Game, e GameEvent) {
+	r.events = append(r.events, e)
+}
+
+func (r *recordingListener) types() []GameEventType {
+	types := make([]GameEventType, len(r.events))
+	for i, e := range r.events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+func eventTypesEqual(a, b []GameEventType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestListenerMovePushedAndNavigation(t *testing.T) {
+	l := &recordingListener{}
+	g := NewGame(WithListener(l))
+
+	if err := g.PushNotationMove("e4", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.PushNotationMove("e5", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	g.GoBack()
+	if err := g.PushNotationMove("c5", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	g.GoForward() // at leaf, no event
+	g.NavigateToMainLine()
+
+	expected := []GameEventType{
+		MovePushedEvent, MovePushedEvent, NavigationEvent, MovePushedEvent, NavigationEvent,
+	}
+	if !eventTypesEqual(l.types(), expected) {
+		t.Fatalf("expected events %v but got %v", expected, l.types())
+	}
+	if l.events[3].Move.String() != "c7c5" {
+		t.Fatalf("expected variation move c7c5 but got %s", l.events[3].Move)
+	}
+}
+
+func TestListenerOutcomeChanged(t *testing.T) {
+	l := &recordingListener{}
+	g := NewGame(WithListener(l))
+	for _, m := range []string{"f3", "e5", "g4", "Qh4#"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	last := l.events[len(l.events)-1]
+	if last.Type != OutcomeChangedEvent || last.Outcome != BlackWon || last.Method != Checkmate {
+		t.Fatalf("expected checkmate outcome event but got %+v", last)
+	}
+
+	l.events = nil
+	g = NewGame(WithListener(l))
+	g.Resign(White)
+	g.Resign(Black) // game already over, no event
+	if !eventTypesEqual(l.types(), []GameEventType{OutcomeChangedEvent}) {
+		t.Fatalf("expected a single outcome event but got %v", l.types())
+	}
+}
+
+func TestListenerTagsAndComments(t *testing.T) {
+	l := &recordingListener{}
+	g := NewGame(WithListener(l))
+	g.AddTagPair("Event", "Test")
+	g.RemoveTagPair("Event")
+	g.RemoveTagPair("Event") // missing, no event
+	if err := g.PushNotationMove("d4", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	g.SetComment(g.Moves()[0], "queen pawn")
+	g.AddComment(g.Moves()[0], " opening")
+
+	expected := []GameEventType{
+		TagChangedEvent, TagChangedEvent, MovePushedEvent, CommentChangedEvent, CommentChangedEvent,
+	}
+	if !eventTypesEqual(l.types(), expected) {
+		t.Fatalf("expected events %v but got %v", expected, l.types())
+	}
+	if l.events[0].Key != "Event" || l.events[0].Value != "Test" {
+		t.Fatalf("unexpected tag event %+v", l.events[0])
+	}
+	if l.events[4].Value != "queen pawn opening" {
+		t.Fatalf("expected full comment but got %q", l.events[4].Value)
+	}
+}
+
+func TestListenerNotCloned(t *testing.T) {
+	l := &recordingListener{}
+	g := NewGame(WithListener(l))
+	clone := g.Clone()
+	if err := clone.PushNotationMove("e4", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(l.events) != 0 {
+		t.Fatalf("expected no events from the clone but got %v", l.types())
+	}
+}
@@ -53,10 +53,65 @@ func decodeFEN(fen string) (*Position, error) {
 	}, nil
 }
 
-// preallocated array to avoid strings.Split allocation
+// NormalizeFEN returns the canonical form of a FEN, so that FENs of the same
+// position written by different sources compare equal:
+//   - fields are separated by a single space,
+//   - castle rights whose king or rook left its original square are removed
+//     and the remaining ones are written in the KQkq order,
+//   - the en passant square is removed unless a pawn of the side to move
+//     stands next to the pushed pawn, as in XFENString.
 //
-//nolint:gochecknoglobals // this is a preallocated array.
-var rankBuffer [8]string
+// When ignoreClocks is true, the half move clock and the move number are
+// dropped and the FEN may be given without them.
+//
+// Example:
+//
+//	fen, _ := chess.NormalizeFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR  b  qkQK e3 0 1", false)
+//	fmt.Println(fen) // rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1
+func NormalizeFEN(fen string, ignoreClocks bool) (string, error) {
+	const positionFields = 4
+	fields := strings.Fields(fen)
+	if ignoreClocks && len(fields) == positionFields {
+		fields = append(fields, "0", "1")
+	}
+	pos, err := decodeFEN(strings.Join(fields, " "))
+	if err != nil {
+		return "", err
+	}
+
+	var rights strings.Builder
+	for _, r := range castleRightSquares {
+		if strings.Contains(string(pos.castleRights), r.right) &&
+			pos.board.Piece(r.ksq) == r.king && pos.board.Piece(r.rsq) == r.rook {
+			rights.WriteString(r.right)
+		}
+	}
+	pos.castleRights = "-"
+	if rights.Len() > 0 {
+		pos.castleRights = CastleRights(rights.String())
+	}
+	if !pos.capturableEnPassant() {
+		pos.enPassantSquare = NoSquare
+	}
+
+	normalized := pos.String()
+	if ignoreClocks {
+		normalized = strings.Join(strings.Fields(normalized)[:positionFields], " ")
+	}
+	return normalized, nil
+}
+
+// FENEqual reports whether two FENs describe the same position once
+// normalized with NormalizeFEN. The clocks are not compared when
+// ignoreClocks is true. Invalid FENs are never equal.
+func FENEqual(a, b string, ignoreClocks bool) bool {
+	na, err := NormalizeFEN(a, ignoreClocks)
+	if err != nil {
+		return false
+	}
+	nb, err := NormalizeFEN(b, ignoreClocks)
+	return err == nil && na == nb
+}
 
 const (
 	fileMapSize  = 8
@@ -109,7 +164,9 @@ func fenBoard(boardStr string) (*Board, error) {
 		fileMapPool.Put(fileMap)
 	}()
 
-	// Split string into ranks without allocation
+	// Split string into ranks without allocation; the array is local so
+	// that FEN decoding is safe for concurrent use
+	var rankBuffer [maxRankLen]string
 	rankCount := 0
 	start := 0
 	for i := range len(boardStr) {
@@ -133,3 +133,60 @@ func BenchmarkFenBoard(b *testing.B) {
 		})
 	}
 }
+
+func TestNormalizeFEN(t *testing.T) {
+	tests := []struct {
+		fen          string
+		ignoreClocks bool
+		expected     string
+	}{
+		{
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR  b  qkQK e3 0 1", false,
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1",
+		},
+		{
+			"rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 3", false,
+			"rnbqkbnr/ppp1pppp/8/8/3pP3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 3",
+		},
+		{
+			"r3k3/8/8/8/8/8/8/4K2R w KQkq - 12 40", false,
+			"r3k3/8/8/8/8/8/8/4K2R w Kq - 12 40",
+		},
+		{
+			"4k3/8/8/8/8/8/8/4K3 w KQ - 12 40", true,
+			"4k3/8/8/8/8/8/8/4K3 w - -",
+		},
+		{
+			"4k3/8/8/8/8/8/8/4K3 w - -", true,
+			"4k3/8/8/8/8/8/8/4K3 w - -",
+		},
+	}
+	for _, test := range tests {
+		normalized, err := NormalizeFEN(test.fen, test.ignoreClocks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if normalized != test.expected {
+			t.Errorf("expected %s to be normalized to %s, got %s", test.fen, test.expected, normalized)
+		}
+	}
+
+	if _, err := NormalizeFEN("4k3/8/8/8/8/8/8/4K3 w - -", false); err == nil {
+		t.Error("expected an error for a FEN without clocks")
+	}
+}
+
+func TestFENEqual(t *testing.T) {
+	a := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"
+	b := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b kqKQ - 0 1"
+	if !FENEqual(a, b, false) {
+		t.Errorf("expected %s and %s to be equal", a, b)
+	}
+	c := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 3 7"
+	if FENEqual(a, c, false) || !FENEqual(a, c, true) {
+		t.Errorf("expected %s and %s to differ only by their clocks", a, c)
+	}
+	if FENEqual(a, "invalid", true) {
+		t.Error("expected an invalid FEN not to be equal")
+	}
+}
@@ -0,0 +1,334 @@
+package chess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FENIssueCode identifies a problem found by ValidateFEN. Syntax codes mean
+// that the FEN can't be decoded, legality codes that it can be decoded but
+// describes a position that can't arise in a game.
+type FENIssueCode int
+
+const (
+	// FENFieldCount means that the FEN doesn't have six space separated
+	// fields.
+	FENFieldCount FENIssueCode = iota + 1
+	// FENInvalidBoard means that the board doesn't have eight ranks.
+	FENInvalidBoard
+	// FENInvalidRank means that a rank doesn't describe eight squares.
+	FENInvalidRank
+	// FENInvalidPiece means that a rank contains an unknown character.
+	FENInvalidPiece
+	// FENInvalidTurn means that the side to move is neither w nor b.
+	FENInvalidTurn
+	// FENInvalidCastling means that the castle rights are malformed.
+	FENInvalidCastling
+	// FENInvalidEnPassant means that the en passant square is malformed or
+	// not on the third or sixth rank.
+	FENInvalidEnPassant
+	// FENInvalidHalfMoveClock means that the half move clock isn't a
+	// non-negative number.
+	FENInvalidHalfMoveClock
+	// FENInvalidMoveNumber means that the move number isn't a positive
+	// number.
+	FENInvalidMoveNumber
+
+	// FENKingCount means that a side doesn't have exactly one king.
+	FENKingCount
+	// FENKingsAdjacent means that the kings are on adjacent squares.
+	FENKingsAdjacent
+	// FENTooManyPawns means that a side has more than eight pawns.
+	FENTooManyPawns
+	// FENTooManyPieces means that a side has more pieces than its pawns
+	// could have promoted to.
+	FENTooManyPieces
+	// FENPawnOnBackRank means that a pawn is on the first or eighth rank.
+	FENPawnOnBackRank
+	// FENOpponentInCheck means that the side not to move is in check.
+	FENOpponentInCheck
+	// FENImpossibleCastling means that a castle right is given although
+	// the king or the rook isn't on its original square.
+	FENImpossibleCastling
+	// FENImpossibleEnPassant means that the en passant square doesn't
+	// follow a pawn double push of the side not to move.
+	FENImpossibleEnPassant
+)
+
+// Indexes of the FEN fields, as reported by FENIssue.Field.
+const (
+	FENFieldBoard = iota
+	FENFieldTurn
+	FENFieldCastling
+	FENFieldEnPassant
+	FENFieldHalfMoveClock
+	FENFieldMoveNumber
+)
+
+//nolint:gochecknoglobals // lookup table.
+var fenIssueCodeNames = map[FENIssueCode]string{
+	FENFieldCount:           "field count",
+	FENInvalidBoard:         "invalid board",
+	FENInvalidRank:          "invalid rank",
+	FENInvalidPiece:         "invalid piece",
+	FENInvalidTurn:          "invalid turn",
+	FENInvalidCastling:      "invalid castle rights",
+	FENInvalidEnPassant:     "invalid en passant square",
+	FENInvalidHalfMoveClock: "invalid half move clock",
+	FENInvalidMoveNumber:    "invalid move number",
+	FENKingCount:            "king count",
+	FENKingsAdjacent:        "kings adjacent",
+	FENTooManyPawns:         "too many pawns",
+	FENTooManyPieces:        "too many pieces",
+	FENPawnOnBackRank:       "pawn on back rank",
+	FENOpponentInCheck:      "opponent in check",
+	FENImpossibleCastling:   "impossible castle rights",
+	FENImpossibleEnPassant:  "impossible en passant square",
+}
+
+func (c FENIssueCode) String() string {
+	if s, ok := fenIssueCodeNames[c]; ok {
+		return s
+	}
+	return "FENIssueCode(" + strconv.Itoa(int(c)) + ")"
+}
+
+// IsSyntax reports whether the code is a syntax problem, i.e. one that
+// prevents the FEN from being decoded.
+func (c FENIssueCode) IsSyntax() bool {
+	return c >= FENFieldCount && c <= FENInvalidMoveNumber
+}
+
+// FENIssue is a problem found in a FEN by ValidateFEN.
+type FENIssue struct {
+	// Field is the index of the field, from FENFieldBoard to
+	// FENFieldMoveNumber, or -1 when the issue is about the whole FEN.
+	Field   int
+	Code    FENIssueCode
+	Message string
+}
+
+func (i FENIssue) String() string {
+	if i.Field < 0 {
+		return fmt.Sprintf("%s: %s", i.Code, i.Message)
+	}
+	return fmt.Sprintf("field %d: %s: %s", i.Field, i.Code, i.Message)
+}
+
+// FENError is returned by FEN with the WithStrictFEN option when the FEN
+// describes an illegal position.
+type FENError struct {
+	FEN    string
+	Issues []FENIssue
+}
+
+func (e *FENError) Error() string {
+	msgs := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		msgs[i] = issue.String()
+	}
+	return fmt.Sprintf("chess: fen %q is not valid: %s", e.FEN, strings.Join(msgs, "; "))
+}
+
+// ValidateFEN checks a FEN and returns every problem found, or nil when
+// the FEN is valid. Syntax problems are reported first; the legality of the
+// position is only checked when the FEN can be decoded.
+//
+// Example:
+//
+//	for _, issue := range chess.ValidateFEN(fen) {
+//	    if issue.Code.IsSyntax() {
+//	        fmt.Println("malformed:", issue)
+//	    }
+//	}
+func ValidateFEN(fen string) []FENIssue {
+	const fields = 6
+	var issues []FENIssue
+	parts := strings.Split(strings.TrimSpace(fen), " ")
+	if len(parts) != fields {
+		issues = append(issues, FENIssue{
+			Field:   -1,
+			Code:    FENFieldCount,
+			Message: fmt.Sprintf("expected %d fields, got %d", fields, len(parts)),
+		})
+	}
+	for len(parts) < fields {
+		parts = append(parts, "")
+	}
+
+	issues = append(issues, validateFENBoard(parts[FENFieldBoard])...)
+	if _, ok := fenTurnMap[parts[FENFieldTurn]]; !ok {
+		issues = append(issues, FENIssue{FENFieldTurn, FENInvalidTurn,
+			fmt.Sprintf("expected w or b, got %q", parts[FENFieldTurn])})
+	}
+	if _, err := formCastleRights(parts[FENFieldCastling]); err != nil || parts[FENFieldCastling] == "" ||
+		len(parts[FENFieldCastling]) > 1 && strings.Contains(parts[FENFieldCastling], "-") {
+		issues = append(issues, FENIssue{FENFieldCastling, FENInvalidCastling,
+			fmt.Sprintf("%q is not - or a combination of KQkq", parts[FENFieldCastling])})
+	}
+	if _, err := formEnPassant(parts[FENFieldEnPassant]); err != nil {
+		issues = append(issues, FENIssue{FENFieldEnPassant, FENInvalidEnPassant,
+			fmt.Sprintf("%q is not - or a square of the third or sixth rank", parts[FENFieldEnPassant])})
+	}
+	if n, err := strconv.Atoi(parts[FENFieldHalfMoveClock]); err != nil || n < 0 {
+		issues = append(issues, FENIssue{FENFieldHalfMoveClock, FENInvalidHalfMoveClock,
+			fmt.Sprintf("%q is not a non-negative number", parts[FENFieldHalfMoveClock])})
+	}
+	if n, err := strconv.Atoi(parts[FENFieldMoveNumber]); err != nil || n < 1 {
+		issues = append(issues, FENIssue{FENFieldMoveNumber, FENInvalidMoveNumber,
+			fmt.Sprintf("%q is not a positive number", parts[FENFieldMoveNumber])})
+	}
+	if len(issues) > 0 {
+		return issues
+	}
+
+	pos, err := decodeFEN(fen)
+	if err != nil {
+		// not expected after the checks above
+		return []FENIssue{{Field: -1, Code: FENFieldCount, Message: err.Error()}}
+	}
+	return validatePosition(pos)
+}
+
+// validateFENBoard checks the syntax of the board field.
+func validateFENBoard(board string) []FENIssue {
+	const ranks = 8
+	var issues []FENIssue
+	rankStrs := strings.Split(board, "/")
+	if len(rankStrs) != ranks {
+		issues = append(issues, FENIssue{FENFieldBoard, FENInvalidBoard,
+			fmt.Sprintf("expected %d ranks, got %d", ranks, len(rankStrs))})
+	}
+	for i, rankStr := range rankStrs {
+		count := 0
+		for j := range len(rankStr) {
+			c := rankStr[j]
+			switch {
+			case c >= '1' && c <= '8':
+				count += int(c - '0')
+			case c < 128 && fenCharToPiece[c] != NoPiece:
+				count++
+			default:
+				issues = append(issues, FENIssue{FENFieldBoard, FENInvalidPiece,
+					fmt.Sprintf("invalid character %q in rank %d", c, ranks-i)})
+				count++
+			}
+		}
+		if count != ranks {
+			issues = append(issues, FENIssue{FENFieldBoard, FENInvalidRank,
+				fmt.Sprintf("rank %d has %d squares", ranks-i, count)})
+		}
+	}
+	return issues
+}
+
+// validatePosition checks the legality of a decoded position.
+func validatePosition(pos *Position) []FENIssue {
+	var issues []FENIssue
+	var counts [2][7]int
+	for sq := range Square(numOfSquaresInBoard) {
+		p := pos.board.Piece(sq)
+		if p == NoPiece {
+			continue
+		}
+		counts[p.Color()-1][p.Type()]++
+		if p.Type() == Pawn && (sq.Rank() == Rank1 || sq.Rank() == Rank8) {
+			issues = append(issues, FENIssue{FENFieldBoard, FENPawnOnBackRank,
+				fmt.Sprintf("%s pawn on %s", p.Color().Name(), sq)})
+		}
+	}
+
+	for _, c := range []Color{White, Black} {
+		n := counts[c-1]
+		name := c.Name()
+		if n[King] != 1 {
+			issues = append(issues, FENIssue{FENFieldBoard, FENKingCount,
+				fmt.Sprintf("%s has %d kings", name, n[King])})
+		}
+		if n[Pawn] > 8 {
+			issues = append(issues, FENIssue{FENFieldBoard, FENTooManyPawns,
+				fmt.Sprintf("%s has %d pawns", name, n[Pawn])})
+		}
+		// pieces beyond the initial ones must come from promotions
+		promoted := max(n[Queen]-1, 0) + max(n[Rook]-2, 0) + max(n[Bishop]-2, 0) + max(n[Knight]-2, 0)
+		if promoted > max(8-n[Pawn], 0) {
+			issues = append(issues, FENIssue{FENFieldBoard, FENTooManyPieces,
+				fmt.Sprintf("%s has %d promoted pieces and %d pawns", name, promoted, n[Pawn])})
+		}
+	}
+
+	wk, bk := pos.board.whiteKingSq, pos.board.blackKingSq
+	if counts[White-1][King] == 1 && counts[Black-1][King] == 1 {
+		opp := *pos
+		opp.turn = pos.turn.Other()
+		df, dr := int(wk.File())-int(bk.File()), int(wk.Rank())-int(bk.Rank())
+		switch {
+		case df >= -1 && df <= 1 && dr >= -1 && dr <= 1:
+			// the kings attack each other, no need to report the check too
+			issues = append(issues, FENIssue{FENFieldBoard, FENKingsAdjacent,
+				fmt.Sprintf("kings on %s and %s", wk, bk)})
+		case isInCheck(&opp):
+			issues = append(issues, FENIssue{FENFieldTurn, FENOpponentInCheck,
+				fmt.Sprintf("%s is to move but %s is in check", pos.turn.Name(), opp.turn.Name())})
+		}
+	}
+
+	issues = append(issues, validateCastleRights(pos)...)
+	if issue, ok := validateEnPassant(pos); !ok {
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// castleRightSquares lists, in the canonical FEN order, the original
+// squares of the king and the rook of each castle right.
+//
+//nolint:gochecknoglobals // lookup table.
+var castleRightSquares = []struct {
+	right      string
+	king, rook Piece
+	ksq, rsq   Square
+}{
+	{"K", WhiteKing, WhiteRook, E1, H1},
+	{"Q", WhiteKing, WhiteRook, E1, A1},
+	{"k", BlackKing, BlackRook, E8, H8},
+	{"q", BlackKing, BlackRook, E8, A8},
+}
+
+// validateCastleRights checks that the king and the rook of each castle
+// right are on their original squares.
+func validateCastleRights(pos *Position) []FENIssue {
+	var issues []FENIssue
+	for _, r := range castleRightSquares {
+		if !strings.Contains(string(pos.castleRights), r.right) {
+			continue
+		}
+		if pos.board.Piece(r.ksq) != r.king || pos.board.Piece(r.rsq) != r.rook {
+			issues = append(issues, FENIssue{FENFieldCastling, FENImpossibleCastling,
+				fmt.Sprintf("castle right %s needs a king on %s and a rook on %s", r.right, r.ksq, r.rsq)})
+		}
+	}
+	return issues
+}
+
+// validateEnPassant checks that the en passant square follows a pawn
+// double push of the side not to move.
+func validateEnPassant(pos *Position) (FENIssue, bool) {
+	sq := pos.enPassantSquare
+	if sq == NoSquare {
+		return FENIssue{}, true
+	}
+	// the pushed pawn is in front of the square, coming from behind it
+	rank, pawnRank, fromRank, pawn := Rank6, Rank5, Rank7, BlackPawn
+	if pos.turn == Black {
+		rank, pawnRank, fromRank, pawn = Rank3, Rank4, Rank2, WhitePawn
+	}
+	if sq.Rank() == rank && pos.board.Piece(sq) == NoPiece &&
+		pos.board.Piece(NewSquare(sq.File(), fromRank)) == NoPiece &&
+		pos.board.Piece(NewSquare(sq.File(), pawnRank)) == pawn {
+		return FENIssue{}, true
+	}
+	return FENIssue{FENFieldEnPassant, FENImpossibleEnPassant,
+		fmt.Sprintf("no %s pawn can have just moved through %s", pawn.Color().Name(), sq)}, false
+}
@@ -0,0 +1,91 @@
+package chess
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateFEN(t *testing.T) {
+	type expected struct {
+		field int
+		code  FENIssueCode
+	}
+	tests := []struct {
+		fen    string
+		issues []expected
+	}{
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", nil},
+		{"rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2", nil},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq -", []expected{
+			{-1, FENFieldCount}, {FENFieldHalfMoveClock, FENInvalidHalfMoveClock}, {FENFieldMoveNumber, FENInvalidMoveNumber},
+		}},
+		{"rnbqkbnr/ppppXppp/9/8/8/8/PPPPPPPP w KQkx e4 -1 0", []expected{
+			{FENFieldBoard, FENInvalidBoard},
+			{FENFieldBoard, FENInvalidPiece},
+			{FENFieldBoard, FENInvalidPiece},
+			{FENFieldBoard, FENInvalidRank},
+			{FENFieldCastling, FENInvalidCastling},
+			{FENFieldEnPassant, FENInvalidEnPassant},
+			{FENFieldHalfMoveClock, FENInvalidHalfMoveClock},
+			{FENFieldMoveNumber, FENInvalidMoveNumber},
+		}},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR x KQkq - 0 1", []expected{
+			{FENFieldTurn, FENInvalidTurn},
+		}},
+		{"4k3/pppppppp/p7/8/8/8/PPPPPPPP/4K3 w - - 0 1", []expected{
+			{FENFieldBoard, FENTooManyPawns},
+		}},
+		{"4k3/8/8/8/8/8/PPPPPPP1/QQQ1K3 w - - 0 1", []expected{
+			{FENFieldBoard, FENTooManyPieces},
+		}},
+		{"8/8/8/3kK3/8/8/8/8 w - - 0 1", []expected{
+			{FENFieldBoard, FENKingsAdjacent},
+		}},
+		{"P3k3/8/8/8/8/8/8/4K2K w - - 0 1", []expected{
+			{FENFieldBoard, FENPawnOnBackRank}, {FENFieldBoard, FENKingCount},
+		}},
+		{"4k3/8/8/8/8/8/8/4R1K1 w - - 0 1", []expected{
+			{FENFieldTurn, FENOpponentInCheck},
+		}},
+		{"r3k3/8/8/8/8/8/8/R3K1R1 w KQkq - 0 1", []expected{
+			{FENFieldCastling, FENImpossibleCastling}, {FENFieldCastling, FENImpossibleCastling},
+		}},
+		{"4k3/8/8/8/4P3/8/8/4K3 w - e3 0 1", []expected{
+			{FENFieldEnPassant, FENImpossibleEnPassant},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.fen, func(t *testing.T) {
+			issues := ValidateFEN(test.fen)
+			if len(issues) != len(test.issues) {
+				t.Fatalf("expected %d issues, got %v", len(test.issues), issues)
+			}
+			for i, exp := range test.issues {
+				if issues[i].Field != exp.field || issues[i].Code != exp.code {
+					t.Errorf("expected issue %d to be %v on field %d, got %v", i, exp.code, exp.field, issues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFENIssueCodeIsSyntax(t *testing.T) {
+	if !FENInvalidMoveNumber.IsSyntax() || FENKingCount.IsSyntax() {
+		t.Error("unexpected syntax classification")
+	}
+}
+
+func TestStrictFEN(t *testing.T) {
+	const illegal = "8/8/8/3kK3/8/8/8/8 w - - 0 1"
+	if _, err := FEN(illegal); err != nil {
+		t.Fatalf("expected the position to be accepted by default, got %v", err)
+	}
+	_, err := FEN(illegal, WithStrictFEN())
+	var ferr *FENError
+	if !errors.As(err, &ferr) || len(ferr.Issues) != 1 || ferr.Issues[0].Code != FENKingsAdjacent {
+		t.Fatalf("expected a FENError for adjacent kings, got %v", err)
+	}
+	if _, err := FEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", WithStrictFEN()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -86,16 +86,19 @@ type TagPairs map[string]string
 
 // A Game represents a single chess game.
 type Game struct {
-	pos                            *Position  // Current position
-	outcome                        Outcome    // Game result
-	tagPairs                       TagPairs   // PGN tag pairs
-	rootMove                       *Move      // Root of move tree
-	currentMove                    *Move      // Current position in tree
-	comments                       [][]string // Game comments
-	method                         Method     // How the game ended
-	ignoreFivefoldRepetitionDraw   bool       // Flag for automatic FivefoldRepetition draw handling
-	ignoreSeventyFiveMoveRuleDraw  bool       // Flag for automatic SeventyFiveMoveRule draw handling
-	ignoreInsufficientMaterialDraw bool       // Flag for automatic InsufficientMaterial draw handling
+	pos                            *Position      // Current position
+	outcome                        Outcome        // Game result
+	tagPairs                       TagPairs       // PGN tag pairs
+	rootMove                       *Move          // Root of move tree
+	currentMove                    *Move          // Current position in tree
+	comments                       [][]string     // Game comments
+	listeners                      []GameListener // Registered event listeners
+	takenBack                      []*Move        // Lines removed by Takeback, attached when a move is played from their parent
+	method                         Method         // How the game ended
+	warnings                       []ParserError  // Problems recovered by lenient parsing
+	ignoreFivefoldRepetitionDraw   bool           // Flag for automatic FivefoldRepetition draw handling
+	ignoreSeventyFiveMoveRuleDraw  bool           // Flag for automatic SeventyFiveMoveRule draw handling
+	ignoreInsufficientMaterialDraw bool           // Flag for automatic InsufficientMaterial draw handling
 }
 
 // PGN takes a reader and returns a function that updates
@@ -137,11 +140,24 @@ func PGN(r io.Reader) (func(*Game), error) {
 // prior moves, the move list will be empty.  The returned
 // function is designed to be used in the NewGame constructor.
 // An error is returned if there is a problem parsing the FEN data.
-func FEN(fen string) (func(*Game), error) {
+//
+// By default any position that can be decoded is accepted; with the
+// WithStrictFEN option, positions that can't arise in a game are rejected
+// with a *FENError.
+func FEN(fen string, opts ...FENOption) (func(*Game), error) {
+	var o fenOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
 	pos, err := decodeFEN(fen)
 	if err != nil {
 		return nil, err
 	}
+	if o.strict {
+		if issues := ValidateFEN(fen); len(issues) > 0 {
+			return nil, &FENError{FEN: fen, Issues: issues}
+		}
+	}
 	if pos == nil {
 		return nil, errors.New("chess: invalid FEN")
 	}
@@ -149,10 +165,27 @@ func FEN(fen string) (func(*Game), error) {
 		pos.inCheck = isInCheck(pos)
 		g.pos = pos
 		g.rootMove.position = pos
+		prevOutcome, prevMethod := g.outcome, g.method
 		g.evaluatePositionStatus()
+		g.emitOutcomeChange(prevOutcome, prevMethod)
 	}, nil
 }
 
+// FENOption configures how FEN decodes a position.
+type FENOption func(*fenOptions)
+
+type fenOptions struct {
+	strict bool
+}
+
+// WithStrictFEN instructs FEN to reject positions that are syntactically
+// valid but illegal, as reported by ValidateFEN.
+func WithStrictFEN() FENOption {
+	return func(o *fenOptions) {
+		o.strict = true
+	}
+}
+
 // NewGame returns a new game in the standard starting position.
 // Optional functions can be provided to configure the initial game state.
 //
@@ -190,6 +223,7 @@ func NewGame(options ...func(*Game)) *Game {
 func (g *Game) AddVariation(parent *Move, newMove *Move) {
 	parent.children = append(parent.children, newMove)
 	newMove.parent = parent
+	g.emit(GameEvent{Type: MovePushedEvent, Move: newMove})
 }
 
 // NavigateToMainLine navigates to the main line of the game.
@@ -205,11 +239,11 @@ func (g *Game) NavigateToMainLine() {
 	// If there are no moves in the game, stay at root
 	if len(g.rootMove.children) == 0 {
 		g.currentMove = g.rootMove
-		return
+	} else {
+		// Otherwise, navigate to the first move of the main line
+		g.currentMove = g.rootMove.children[0]
 	}
-
-	// Otherwise, navigate to the first move of the main line
-	g.currentMove = g.rootMove.children[0]
+	g.emit(GameEvent{Type: NavigationEvent, Move: g.currentMove})
 }
 
 func isMainLine(move *Move) bool {
@@ -226,6 +260,7 @@ func (g *Game) GoBack() bool {
 	if g.currentMove != nil && g.currentMove.parent != nil {
 		g.currentMove = g.currentMove.parent
 		g.pos = g.currentMove.position.copy()
+		g.emit(GameEvent{Type: NavigationEvent, Move: g.currentMove})
 		return true
 	}
 	return false
@@ -239,6 +274,7 @@ func (g *Game) GoForward() bool {
 	if g.currentMove != nil && len(g.currentMove.children) > 0 {
 		g.currentMove = g.currentMove.children[0] // Follow main line
 		g.pos = g.currentMove.position
+		g.emit(GameEvent{Type: NavigationEvent, Move: g.currentMove})
 		return true
 	}
 	return false
@@ -304,6 +340,12 @@ func (g *Game) Comments() [][]string {
 	return append([][]string(nil), g.comments...)
 }
 
+// Warnings returns the problems recovered from while parsing the game
+// in lenient mode. It is empty for games parsed in strict mode.
+func (g *Game) Warnings() []ParserError {
+	return append([]ParserError(nil), g.warnings...)
+}
+
 // Position returns the game's current position.
 func (g *Game) Position() *Position {
 	return g.pos
@@ -606,8 +648,10 @@ func (g *Game) Draw(method Method) error {
 	default:
 		return errors.New("chess: invalid draw method")
 	}
+	prevOutcome, prevMethod := g.outcome, g.method
 	g.outcome = Draw
 	g.method = method
+	g.emitOutcomeChange(prevOutcome, prevMethod)
 	return nil
 }
 
@@ -617,12 +661,14 @@ func (g *Game) Resign(color Color) {
 	if g.outcome != NoOutcome || color == NoColor {
 		return
 	}
+	prevOutcome, prevMethod := g.outcome, g.method
 	if color == White {
 		g.outcome = BlackWon
 	} else {
 		g.outcome = WhiteWon
 	}
 	g.method = Resignation
+	g.emitOutcomeChange(prevOutcome, prevMethod)
 }
 
 // EligibleDraws returns valid inputs for the Draw() method.
@@ -646,12 +692,10 @@ func (g *Game) AddTagPair(k, v string) bool {
 	if g.tagPairs == nil {
 		g.tagPairs = make(map[string]string)
 	}
-	if _, existing := g.tagPairs[k]; existing {
-		g.tagPairs[k] = v
-		return true
-	}
+	_, existing := g.tagPairs[k]
 	g.tagPairs[k] = v
-	return false
+	g.emit(GameEvent{Type: TagChangedEvent, Key: k, Value: v})
+	return existing
 }
 
 // GetTagPair returns the tag pair for the given key or nil
@@ -660,11 +704,21 @@ func (g *Game) GetTagPair(k string) string {
 	return g.tagPairs[k]
 }
 
+// TagPairs returns a copy of the tag pairs of the game.
+func (g *Game) TagPairs() TagPairs {
+	tags := make(TagPairs, len(g.tagPairs))
+	for k, v := range g.tagPairs {
+		tags[k] = v
+	}
+	return tags
+}
+
 // RemoveTagPair removes the tag pair for the given key and
 // returns true if a tag pair was removed.
 func (g *Game) RemoveTagPair(k string) bool {
 	if _, existing := g.tagPairs[k]; existing {
 		delete(g.tagPairs, k)
+		g.emit(GameEvent{Type: TagChangedEvent, Key: k})
 		return true
 	}
 
@@ -709,6 +763,8 @@ func (g *Game) evaluatePositionStatus() {
 }
 
 // copy copies the game state from the given game.
+// The listeners of the receiver are kept and those of the given
+// game are not copied; no event is emitted.
 func (g *Game) copy(game *Game) {
 	g.tagPairs = make(map[string]string)
 	for k, v := range game.tagPairs {
@@ -720,12 +776,18 @@ func (g *Game) copy(game *Game) {
 	g.outcome = game.outcome
 	g.method = game.method
 	g.comments = game.Comments()
+	g.warnings = game.Warnings()
 	g.ignoreFivefoldRepetitionDraw = game.ignoreFivefoldRepetitionDraw
 	g.ignoreSeventyFiveMoveRuleDraw = game.ignoreSeventyFiveMoveRuleDraw
 	g.ignoreInsufficientMaterialDraw = game.ignoreInsufficientMaterialDraw
+	// lines held aside belong to the move tree of the given game
+	g.takenBack = nil
 }
 
 // Clone returns a deep copy of the game.
+// Listeners are not part of the game state: the clone starts without
+// any registered listener and changes to it are not reported to the
+// listeners of the original game.
 func (g *Game) Clone() *Game {
 	ret := &Game{}
 	ret.copy(g)
@@ -734,6 +796,14 @@ func (g *Game) Clone() *Game {
 	// clone do not impact the parent
 	ret.rootMove = g.rootMove.Clone()
 	ret.rootMove.cloneChildren(g.rootMove.children)
+	for _, line := range g.takenBack {
+		if parent := ret.rootMove.follow(pathTo(line.parent)); parent != nil {
+			cp := line.Clone()
+			cp.parent = parent
+			cp.cloneChildren(line.children)
+			ret.takenBack = append(ret.takenBack, cp)
+		}
+	}
 	mlen := len(ret.Moves())
 	if mlen == 0 {
 		ret.currentMove = ret.rootMove
@@ -893,17 +963,138 @@ func (g *Game) moveUnchecked(move *Move, options *PushMoveOptions) error {
 		return errors.New("move cannot be nil")
 	}
 
+	replayed, variations := g.takeTakenBack(move)
+	if replayed != nil {
+		// the continuations of a replayed move stay aside until the next move
+		g.takenBack = append(g.takenBack, replayed.children...)
+		replayed.children = nil
+		g.currentMove.children = append([]*Move{replayed}, g.currentMove.children...)
+		move = replayed
+	}
+
 	existingMove := g.findExistingMove(move)
 	g.addOrReorderMove(move, existingMove, options.ForceMainline)
+	g.currentMove.children = append(g.currentMove.children, variations...)
 
 	g.updatePosition(move)
 	g.currentMove = move
 
+	prevOutcome, prevMethod := g.outcome, g.method
+	g.evaluatePositionStatus()
+
+	g.emit(GameEvent{Type: MovePushedEvent, Move: move})
+	g.emitOutcomeChange(prevOutcome, prevMethod)
+
+	return nil
+}
+
+// TakebackOptions contains options for taking back moves.
+type TakebackOptions struct {
+	// KeepAsVariation keeps the removed moves so that they become a
+	// variation of the next move played from the restored position.
+	KeepAsVariation bool
+}
+
+// Takeback removes the last n moves of the main line and makes the last
+// remaining move the current one. The position, the outcome, the method
+// and the repetition history are restored as they were before the removed
+// moves were played, so a finished game can be continued.
+//
+// Without options the removed moves are discarded and, if the restored
+// move had variations, the first of them becomes the main line.
+//
+// With KeepAsVariation the removed moves are kept. If the restored move
+// had variations, they are appended to them. Otherwise they are held aside,
+// out of the main line, until a move is played from the restored move:
+// they then become a variation of that move or, if the same move is played
+// again, the main line once more, its own continuations being held aside in
+// turn. Held aside moves are carried by Clone but not by the PGN, JSON and
+// binary encodings.
+//
+// An error is returned if n is not between 1 and the number of moves of the
+// main line.
+//
+// Example:
+//
+//	// take back the last full move and play another one in its place
+//	err := game.Takeback(2, &TakebackOptions{KeepAsVariation: true})
+//	err = game.PushNotationMove("Bc4", chess.AlgebraicNotation{}, nil)
+func (g *Game) Takeback(n int, options *TakebackOptions) error {
+	if options == nil {
+		options = &TakebackOptions{}
+	}
+
+	moves := g.Moves()
+	if n < 1 || n > len(moves) {
+		return fmt.Errorf("chess: cannot take back %d moves from a game with %d moves", n, len(moves))
+	}
+
+	removed := moves[len(moves)-n]
+	target := removed.parent
+	target.children = target.children[1:]
+
+	// lines held aside from a removed move go with it
+	g.takenBack = slices.DeleteFunc(g.takenBack, func(line *Move) bool {
+		if !isDescendant(line.parent, removed) {
+			return false
+		}
+		if options.KeepAsVariation {
+			line.parent.children = append(line.parent.children, line)
+		}
+		return true
+	})
+	switch {
+	case !options.KeepAsVariation:
+	case len(target.children) > 0:
+		target.children = append(target.children, removed)
+	default:
+		g.takenBack = append(g.takenBack, removed)
+	}
+
+	g.currentMove = target
+	g.pos = target.position.copy()
+
+	prevOutcome, prevMethod := g.outcome, g.method
+	g.outcome = NoOutcome
+	g.method = NoMethod
 	g.evaluatePositionStatus()
 
+	g.emit(GameEvent{Type: TakebackEvent, Move: target})
+	g.emitOutcomeChange(prevOutcome, prevMethod)
+
 	return nil
 }
 
+// takeTakenBack removes the lines held aside from the current move. The
+// line starting with move, if any, is returned as replayed and the others
+// as variations to attach once move is played.
+func (g *Game) takeTakenBack(move *Move) (*Move, []*Move) {
+	var replayed *Move
+	var variations []*Move
+	g.takenBack = slices.DeleteFunc(g.takenBack, func(line *Move) bool {
+		if line.parent != g.currentMove {
+			return false
+		}
+		if replayed == nil && line.s1 == move.s1 && line.s2 == move.s2 && line.promo == move.promo {
+			replayed = line
+		} else {
+			variations = append(variations, line)
+		}
+		return true
+	})
+	return replayed, variations
+}
+
+// isDescendant reports whether m is ancestor or one of its descendants.
+func isDescendant(m, ancestor *Move) bool {
+	for ; m != nil; m = m.parent {
+		if m == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
 // validateMove checks if the given move is valid for the current position.
 // It returns an error if the move is invalid.
 func (g *Game) validateMove(move *Move) error {
@@ -2373,3 +2373,214 @@ func TestCastlingInteractions(t *testing.T) {
 		})
 	}
 }
+
+func TestTakebackRestoresOutcome(t *testing.T) {
+	g := NewGame()
+	for _, m := range []string{"f3", "e5", "g4", "Qh4#"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.Outcome() != BlackWon {
+		t.Fatalf("expected outcome %s but got %s", BlackWon, g.Outcome())
+	}
+
+	if err := g.Takeback(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if g.Outcome() != NoOutcome || g.Method() != NoMethod {
+		t.Fatalf("expected game in progress but got %s by %s", g.Outcome(), g.Method())
+	}
+	if len(g.Moves()) != 3 {
+		t.Fatalf("expected 3 moves but got %d", len(g.Moves()))
+	}
+	if g.Position().Turn() != Black {
+		t.Fatalf("expected black to move")
+	}
+
+	if err := g.PushNotationMove("d6", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if g.Outcome() != NoOutcome {
+		t.Fatalf("expected game in progress but got %s", g.Outcome())
+	}
+	if got := len(g.GetRootMove().children[0].children[0].children[0].children); got != 1 {
+		t.Fatalf("expected the taken back move to be discarded, got %d children", got)
+	}
+}
+
+func TestTakebackKeepAsVariation(t *testing.T) {
+	g := NewGame()
+	for _, m := range []string{"e4", "e5", "Nf3", "Nc6"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Takeback(2, &TakebackOptions{KeepAsVariation: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Moves()) != 2 || g.currentMove != g.Moves()[1] {
+		t.Fatalf("expected e5 to be the current move, got %v", g.currentMove)
+	}
+	// the removed line is held aside until the next move
+	if strings.Contains(g.String(), "Nf3") {
+		t.Fatalf("expected the taken back line out of %s", g.String())
+	}
+	clone := g.Clone()
+
+	if err := g.PushNotationMove("Bc4", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := clone.PushNotationMove("Bc4", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(clone.String(), "(2. Nf3 Nc6)") {
+		t.Fatalf("expected the taken back line as variation in the clone, got %s", clone.String())
+	}
+	mainline := getMainline(g)
+	if !moveSlicesEqual(mainline, []string{"e2e4", "e7e5", "f1c4"}) {
+		t.Fatalf("unexpected main line %v", mainline)
+	}
+	vars := g.Variations(g.Moves()[1])
+	if len(vars) != 1 || vars[0].String() != "g1f3" || len(vars[0].children) != 1 {
+		t.Fatalf("expected taken back line Nf3 Nc6 as variation, got %v", vars)
+	}
+}
+
+func TestTakebackKeepAsVariationNoAlternative(t *testing.T) {
+	g := NewGame()
+	for _, m := range []string{"f3", "e5", "g4", "Qh4#"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	keep := &TakebackOptions{KeepAsVariation: true}
+	if err := g.Takeback(1, keep); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Moves()) != 3 || len(g.Positions()) != 4 {
+		t.Fatalf("expected 3 moves and 4 positions, got %d and %d", len(g.Moves()), len(g.Positions()))
+	}
+	if strings.Contains(g.String(), "Qh4") || g.Outcome() != NoOutcome {
+		t.Fatalf("expected Qh4# to be taken back, got %s", g.String())
+	}
+
+	if err := g.Takeback(1, keep); err != nil {
+		t.Fatal(err)
+	}
+	if mainline := getMainline(g); !moveSlicesEqual(mainline, []string{"f2f3", "e7e5"}) {
+		t.Fatalf("unexpected main line %v", mainline)
+	}
+
+	if err := g.PushNotationMove("g4", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if mainline := getMainline(g); !moveSlicesEqual(mainline, []string{"f2f3", "e7e5", "g2g4"}) {
+		t.Fatalf("unexpected main line %v", mainline)
+	}
+	// g4 is played again, so its taken back continuation is a variation of
+	// the next move
+	if err := g.PushNotationMove("Qf6", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if mainline := getMainline(g); !moveSlicesEqual(mainline, []string{"f2f3", "e7e5", "g2g4", "d8f6"}) {
+		t.Fatalf("unexpected main line %v", mainline)
+	}
+	if !strings.Contains(g.String(), "(2... Qh4#)") {
+		t.Fatalf("expected Qh4# as variation, got %s", g.String())
+	}
+}
+
+func TestTakebackKeepAsVariationWithVariations(t *testing.T) {
+	g := NewGame()
+	for _, m := range []string{"e4", "e5"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g.GoBack()
+	if err := g.PushNotationMove("c5", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	g.GoBack()
+	g.GoForward()
+	if err := g.Takeback(1, &TakebackOptions{KeepAsVariation: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// navigating away doesn't lose the removed line
+	g.GoBack()
+	children := g.GetRootMove().children[0].children
+	if len(children) != 2 || children[0].String() != "c7c5" || children[1].String() != "e7e5" {
+		t.Fatalf("expected c5 as main line and e5 as variation, got %v", children)
+	}
+	if err := g.PushNotationMove("d4", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(g.GetRootMove().children[0].children) != 2 {
+		t.Fatalf("expected the removed line to be kept")
+	}
+}
+
+func TestTakebackReplaySameMove(t *testing.T) {
+	g := NewGame()
+	for _, m := range []string{"d4", "d5", "c4"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Takeback(2, &TakebackOptions{KeepAsVariation: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []string{"d5", "c4"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+		if g.currentMove != g.Moves()[len(g.Moves())-1] {
+			t.Fatalf("expected %s to end the main line, got %v", m, getMainline(g))
+		}
+	}
+	if !moveSlicesEqual(getMainline(g), []string{"d2d4", "d7d5", "c2c4"}) {
+		t.Fatalf("expected the original line to be restored, got %v", getMainline(g))
+	}
+	if len(g.Variations(g.Moves()[0])) != 0 || len(g.Variations(g.Moves()[1])) != 0 {
+		t.Fatalf("expected no variation")
+	}
+}
+
+func TestTakebackRepetitionHistory(t *testing.T) {
+	g := NewGame()
+	for _, m := range []string{"Nf3", "Nf6", "Ng1", "Ng8", "Nf3", "Nf6", "Ng1", "Ng8"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.Draw(ThreefoldRepetition); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Takeback(4, nil); err != nil {
+		t.Fatal(err)
+	}
+	if g.Outcome() != NoOutcome {
+		t.Fatalf("expected game in progress but got %s", g.Outcome())
+	}
+	if err := g.Draw(ThreefoldRepetition); err == nil {
+		t.Fatalf("expected threefold repetition to be unavailable after takeback")
+	}
+}
+
+func TestTakebackInvalidCount(t *testing.T) {
+	g := NewGame()
+	if err := g.Takeback(1, nil); err == nil {
+		t.Fatalf("expected error taking back from an empty game")
+	}
+	if err := g.PushNotationMove("e4", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Takeback(0, nil); err == nil {
+		t.Fatalf("expected error taking back zero moves")
+	}
+	if err := g.Takeback(2, nil); err == nil {
+		t.Fatalf("expected error taking back more moves than played")
+	}
+}
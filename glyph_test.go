@@ -0,0 +1,134 @@
+package chess
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseGlyph(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Glyph
+	}{
+		{"$0", GlyphNull},
+		{"$16", GlyphWhiteBetter},
+		{"$255", 255},
+		{"!?", GlyphInterestingMove},
+		{"??", GlyphBlunder},
+		{"±", GlyphWhiteBetter},
+		{"+/-", GlyphWhiteBetter},
+		{"=+", GlyphBlackSlightlyBetter},
+		{"→", 36},
+		{"N", GlyphNovelty},
+	}
+	for _, tt := range tests {
+		g, err := ParseGlyph(tt.input)
+		if err != nil {
+			t.Fatalf("ParseGlyph(%q): unexpected error %v", tt.input, err)
+		}
+		if g != tt.expected {
+			t.Errorf("ParseGlyph(%q) = %v, expected %v", tt.input, g, tt.expected)
+		}
+	}
+
+	for _, input := range []string{"", "$", "$256", "$-1", "$1x", "!!!", "foo"} {
+		if _, err := ParseGlyph(input); err == nil {
+			t.Errorf("ParseGlyph(%q): expected an error", input)
+		}
+	}
+}
+
+func TestGlyphTable(t *testing.T) {
+	if s := GlyphWhiteBetter.String(); s != "$16" {
+		t.Errorf("expected $16, got %s", s)
+	}
+	if s := GlyphWhiteBetter.Symbol(); s != "±" {
+		t.Errorf("expected ±, got %s", s)
+	}
+	if d := GlyphWhiteBetter.Description(); d != "White has a moderate advantage" {
+		t.Errorf("unexpected description %q", d)
+	}
+	if s, d := Glyph(200).Symbol(), Glyph(200).Description(); s != "" || d != "" {
+		t.Errorf("expected $200 to be unassigned, got %q %q", s, d)
+	}
+	for g := range Glyph(140) {
+		if Glyph(g).Description() == "" {
+			t.Errorf("missing description for %v", Glyph(g))
+		}
+		if s := Glyph(g).Symbol(); s != "" {
+			if parsed, err := ParseGlyph(s); err != nil || parsed.Symbol() != s {
+				t.Errorf("symbol %q of %v doesn't round trip: %v %v", s, Glyph(g), parsed, err)
+			}
+		}
+	}
+}
+
+func TestMoveNAGs(t *testing.T) {
+	opt, err := PGN(strings.NewReader("1. e4 $1 $14 e5 !? 2. Nf3 *"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	moves := NewGame(opt).Moves()
+	if got := moves[0].NAGs(); !slices.Equal(got, []Glyph{GlyphGoodMove, GlyphWhiteSlightlyBetter}) {
+		t.Fatalf("expected two NAGs on 1. e4, got %v", got)
+	}
+	if moves[0].NAG() != "$1" || moves[1].NAG() != "!?" || moves[2].NAG() != "" {
+		t.Fatalf("unexpected first NAGs %q %q %q", moves[0].NAG(), moves[1].NAG(), moves[2].NAG())
+	}
+
+	moves[2].AddNAG(GlyphNovelty)
+	if moves[2].NAG() != "$146" {
+		t.Errorf("expected $146, got %q", moves[2].NAG())
+	}
+	moves[0].SetNAG("?")
+	if got := moves[0].NAGs(); !slices.Equal(got, []Glyph{GlyphMistake}) {
+		t.Errorf("expected SetNAG to replace the NAGs, got %v", got)
+	}
+	moves[0].SetNAG("")
+	if len(moves[0].NAGs()) != 0 {
+		t.Errorf("expected SetNAG to clear the NAGs, got %v", moves[0].NAGs())
+	}
+	moves[0].SetNAGs(GlyphBrilliantMove, GlyphWhiteWinning)
+	if got := moves[0].NAGs(); !slices.Equal(got, []Glyph{GlyphBrilliantMove, GlyphWhiteWinning}) || moves[0].NAG() != "$3" {
+		t.Errorf("expected SetNAGs to replace the NAGs, got %v", got)
+	}
+	moves[0].SetNAGs()
+	if len(moves[0].NAGs()) != 0 {
+		t.Errorf("expected SetNAGs to clear the NAGs, got %v", moves[0].NAGs())
+	}
+}
+
+func TestMoveNAGsRoundTrip(t *testing.T) {
+	g := NewGame()
+	if err := g.PushMove("e4", nil); err != nil {
+		t.Fatal(err)
+	}
+	g.Moves()[0].SetNAG("!?")
+	g.Moves()[0].AddNAG(GlyphWhiteSlightlyBetter)
+	expected := []Glyph{GlyphInterestingMove, GlyphWhiteSlightlyBetter}
+
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromBinary := NewGame()
+	if err := fromBinary.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if got := fromBinary.Moves()[0].NAGs(); !slices.Equal(got, expected) {
+		t.Errorf("binary: expected %v, got %v", expected, got)
+	}
+
+	data, err = g.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON := NewGame()
+	if err := fromJSON.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if got := fromJSON.Moves()[0].NAGs(); !slices.Equal(got, expected) {
+		t.Errorf("json: expected %v, got %v", expected, got)
+	}
+}
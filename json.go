@@ -0,0 +1,260 @@
+package chess
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// gameJSON is the JSON representation of a Game.
+type gameJSON struct {
+	Tags     TagPairs   `json:"tags"`
+	FEN      string     `json:"fen"`
+	Comment  string     `json:"comment,omitempty"`
+	Comments [][]string `json:"comments,omitempty"`
+	Moves    []moveJSON `json:"moves"`
+	Result   string     `json:"result"`
+	Method   string     `json:"method"`
+}
+
+// moveJSON is the JSON representation of a Move.
+type moveJSON struct {
+	SAN        string            `json:"san,omitempty"`
+	UCI        string            `json:"uci"`
+	Comment    string            `json:"comment,omitempty"`
+	NAGs       []string          `json:"nags,omitempty"`
+	Commands   map[string]string `json:"commands,omitempty"`
+	Variations [][]moveJSON      `json:"variations,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. A game is encoded
+// as an object with the following fields:
+//
+//	{
+//	  "tags":     {"Event": "Casual", "White": "Alice", ...},
+//	  "fen":      "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+//	  "comment":  "comment before the first move",
+//	  "comments": [["game comment", ...], ...],
+//	  "moves":    [move, ...],
+//	  "result":   "1-0",
+//	  "method":   "Checkmate"
+//	}
+//
+// "fen" is the starting position of the game, "comments" holds the game
+// comments as returned by Comments, "moves" is the main line and "result"
+// and "method" are the Outcome and Method of the game. Each move
+// is an object:
+//
+//	{
+//	  "san":        "Nf3",
+//	  "uci":        "g1f3",
+//	  "comment":    "text comment",
+//	  "nags":       ["$1"],
+//	  "commands":   {"clk": "0:03:00"},
+//	  "variations": [[move, ...], ...]
+//	}
+//
+// "variations" holds the alternatives to the move: each variation is a list
+// of moves whose first move is played instead of the move holding it.
+// Empty fields are omitted. When decoding, "uci" takes precedence over "san"
+// and only one of them is required.
+func (g *Game) MarshalJSON() ([]byte, error) {
+	tags := g.tagPairs
+	if tags == nil {
+		tags = TagPairs{}
+	}
+	return json.Marshal(gameJSON{
+		Tags:     tags,
+		FEN:      g.rootMove.position.String(),
+		Comment:  g.rootMove.comments,
+		Comments: g.comments,
+		Moves:    encodeJSONLine(g.rootMove),
+		Result:   g.outcome.String(),
+		Method:   g.method.String(),
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Moves are validated while the move tree is rebuilt and an error
+// is returned if one of them is illegal.
+func (g *Game) UnmarshalJSON(data []byte) error {
+	var gj gameJSON
+	if err := json.Unmarshal(data, &gj); err != nil {
+		return err
+	}
+
+	game := NewGame()
+	if gj.FEN != "" {
+		pos, err := decodeFEN(gj.FEN)
+		if err != nil {
+			return err
+		}
+		pos.inCheck = isInCheck(pos)
+		game.rootMove.position = pos
+	}
+	game.rootMove.comments = gj.Comment
+	game.comments = gj.Comments
+	for k, v := range gj.Tags {
+		game.tagPairs[k] = v
+	}
+
+	if err := decodeJSONLine(game.rootMove, gj.Moves); err != nil {
+		return err
+	}
+
+	game.currentMove = game.rootMove
+	for len(game.currentMove.children) > 0 {
+		game.currentMove = game.currentMove.children[0]
+	}
+	game.pos = game.currentMove.position
+
+	outcome, err := outcomeFromString(gj.Result)
+	if err != nil {
+		return err
+	}
+	game.outcome = outcome
+	method, err := methodFromString(gj.Method)
+	if err != nil {
+		return err
+	}
+	game.method = method
+
+	g.copy(game)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The move is encoded
+// as in a game but without its variations; "san" is only present if the
+// move belongs to a game.
+func (m *Move) MarshalJSON() ([]byte, error) {
+	var pos *Position
+	if m.parent != nil {
+		pos = m.parent.position
+	}
+	return json.Marshal(encodeJSONMove(pos, m))
+}
+
+// MarshalJSON implements the json.Marshaler interface and
+// encodes the position as a FEN string.
+func (pos *Position) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pos.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface and
+// assumes the data is a FEN string.
+func (pos *Position) UnmarshalJSON(data []byte) error {
+	var fen string
+	if err := json.Unmarshal(data, &fen); err != nil {
+		return err
+	}
+	return pos.UnmarshalText([]byte(fen))
+}
+
+// encodeJSONMove encodes m played from pos. The SAN is omitted if pos is nil.
+func encodeJSONMove(pos *Position, m *Move) moveJSON {
+	mj := moveJSON{
+		UCI:     UCINotation{}.Encode(pos, m),
+		Comment: m.comments,
+	}
+	if pos != nil {
+		mj.SAN = AlgebraicNotation{}.Encode(pos, m)
+	}
+	if len(m.nags) > 0 {
+		mj.NAGs = m.nags
+	}
+	if len(m.command) > 0 {
+		mj.Commands = m.command
+	}
+	return mj
+}
+
+// encodeJSONLine encodes the main line following parent along with the
+// variations branching off it.
+func encodeJSONLine(parent *Move) []moveJSON {
+	var line []moveJSON
+	for cur := parent; len(cur.children) > 0; cur = cur.children[0] {
+		mj := encodeJSONMove(cur.position, cur.children[0])
+		for _, alt := range cur.children[1:] {
+			variation := append([]moveJSON{encodeJSONMove(cur.position, alt)}, encodeJSONLine(alt)...)
+			mj.Variations = append(mj.Variations, variation)
+		}
+		line = append(line, mj)
+	}
+	return line
+}
+
+// decodeJSONLine appends the given line and its variations below parent.
+func decodeJSONLine(parent *Move, line []moveJSON) error {
+	cur := parent
+	for _, mj := range line {
+		move, err := decodeJSONMove(cur.position, mj)
+		if err != nil {
+			return err
+		}
+		child := cur.appendChild(move)
+
+		for _, variation := range mj.Variations {
+			if err := decodeJSONLine(cur, variation); err != nil {
+				return err
+			}
+		}
+		cur = child
+	}
+	return nil
+}
+
+// decodeJSONMove resolves the move described by mj in pos.
+func decodeJSONMove(pos *Position, mj moveJSON) (*Move, error) {
+	var decoded *Move
+	var err error
+	switch {
+	case mj.UCI != "":
+		decoded, err = UCINotation{}.Decode(pos, mj.UCI)
+	case mj.SAN != "":
+		decoded, err = AlgebraicNotation{}.Decode(pos, mj.SAN)
+	default:
+		return nil, errors.New("chess: json move has neither uci nor san")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	move := legalMove(pos, decoded.s1, decoded.s2, decoded.promo)
+	if move == nil {
+		return nil, fmt.Errorf("chess: json move %s is not valid in position %s", decoded, pos)
+	}
+	move.comments = mj.Comment
+	if len(mj.NAGs) > 0 {
+		move.nags = mj.NAGs
+	}
+	if len(mj.Commands) > 0 {
+		move.command = mj.Commands
+	}
+	return move, nil
+}
+
+// outcomeFromString returns the Outcome written s. An empty string is
+// decoded as NoOutcome.
+func outcomeFromString(s string) (Outcome, error) {
+	switch Outcome(s) {
+	case UnknownOutcome, NoOutcome:
+		return NoOutcome, nil
+	case WhiteWon, BlackWon, Draw:
+		return Outcome(s), nil
+	}
+	return NoOutcome, fmt.Errorf("chess: unknown result %q", s)
+}
+
+// methodFromString returns the Method whose String() is s.
+// An empty string is decoded as NoMethod.
+func methodFromString(s string) (Method, error) {
+	if s == "" {
+		return NoMethod, nil
+	}
+	for m := NoMethod; m <= InsufficientMaterial; m++ {
+		if m.String() == s {
+			return m, nil
+		}
+	}
+	return NoMethod, fmt.Errorf("chess: unknown method %q", s)
+}
@@ -0,0 +1,144 @@
+package chess
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGameJSONRoundTrip(t *testing.T) {
+	pgn := mustParsePGN("fixtures/pgns/variations.pgn")
+	reader := strings.NewReader(pgn)
+	opt, err := PGN(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame(opt)
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := NewGame()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() != g.String() {
+		t.Fatalf("expected round trip PGN\n%s\nbut got\n%s", g.String(), decoded.String())
+	}
+	if decoded.Position().String() != g.Moves()[len(g.Moves())-1].Position().String() {
+		t.Fatalf("expected current position at the end of the main line")
+	}
+}
+
+func TestGameJSONSchema(t *testing.T) {
+	g := NewGame()
+	g.AddTagPair("Event", "Test")
+	for _, m := range []string{"f3", "e5", "g4", "Qh4#"} {
+		if err := g.PushNotationMove(m, AlgebraicNotation{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g.Moves()[0].SetComment("weak")
+	g.Moves()[0].SetNAG("$2")
+	g.Moves()[1].SetCommand("clk", "0:05:00")
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw struct {
+		Tags   map[string]string `json:"tags"`
+		FEN    string            `json:"fen"`
+		Result string            `json:"result"`
+		Method string            `json:"method"`
+		Moves  []struct {
+			SAN      string            `json:"san"`
+			UCI      string            `json:"uci"`
+			Comment  string            `json:"comment"`
+			NAGs     []string          `json:"nags"`
+			Commands map[string]string `json:"commands"`
+		} `json:"moves"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw.Tags["Event"] != "Test" || raw.FEN != startFEN || raw.Result != "0-1" || raw.Method != "Checkmate" {
+		t.Fatalf("unexpected game fields in %s", data)
+	}
+	if len(raw.Moves) != 4 || raw.Moves[3].SAN != "Qh4#" || raw.Moves[3].UCI != "d8h4" {
+		t.Fatalf("unexpected moves in %s", data)
+	}
+	if raw.Moves[0].Comment != "weak" || len(raw.Moves[0].NAGs) != 1 || raw.Moves[0].NAGs[0] != "$2" {
+		t.Fatalf("unexpected annotations in %s", data)
+	}
+	if raw.Moves[1].Commands["clk"] != "0:05:00" {
+		t.Fatalf("unexpected commands in %s", data)
+	}
+}
+
+func TestGameJSONFromSAN(t *testing.T) {
+	data := `{"fen":"7k/8/8/8/8/8/8/R6K w - - 0 1","moves":[{"san":"Ra8#"}],"result":"1-0","method":"Checkmate"}`
+	g := NewGame()
+	if err := json.Unmarshal([]byte(data), g); err != nil {
+		t.Fatal(err)
+	}
+	if g.Outcome() != WhiteWon || g.Method() != Checkmate {
+		t.Fatalf("expected %s by %s but got %s by %s", WhiteWon, Checkmate, g.Outcome(), g.Method())
+	}
+	if len(g.Moves()) != 1 || g.Moves()[0].String() != "a1a8" {
+		t.Fatalf("unexpected moves %v", g.Moves())
+	}
+}
+
+func TestGameJSONIllegalMove(t *testing.T) {
+	data := `{"moves":[{"uci":"e2e5"}]}`
+	if err := json.Unmarshal([]byte(data), NewGame()); err == nil {
+		t.Fatalf("expected error for illegal move")
+	}
+}
+
+func TestGameJSONUnknownResult(t *testing.T) {
+	data := `{"moves":[{"uci":"e2e4"}],"result":"1-O"}`
+	if err := json.Unmarshal([]byte(data), NewGame()); err == nil {
+		t.Fatalf("expected error for an unknown result")
+	}
+}
+
+func TestGameJSONGameComments(t *testing.T) {
+	data := `{"comments":[["first","second"],["third"]],"moves":[{"uci":"e2e4"}],"result":"*"}`
+	g := NewGame()
+	if err := json.Unmarshal([]byte(data), g); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Comments(); len(got) != 2 || got[0][1] != "second" || got[1][0] != "third" {
+		t.Fatalf("unexpected comments %v", got)
+	}
+	out, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"comments":[["first","second"],["third"]]`) {
+		t.Fatalf("expected the game comments in %s", out)
+	}
+}
+
+func TestPositionJSON(t *testing.T) {
+	pos := StartingPosition()
+	data, err := json.Marshal(pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"`+startFEN+`"` {
+		t.Fatalf("unexpected position json %s", data)
+	}
+	decoded := &Position{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() != startFEN {
+		t.Fatalf("expected %s but got %s", startFEN, decoded.String())
+	}
+}
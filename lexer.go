@@ -21,8 +21,10 @@ Example usage:
 package chess
 
 import (
+	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // TokenType represents the type of token in PGN text.
@@ -61,6 +63,7 @@ const (
 	CommandParam        // Command parameter
 	CommandEnd          // ]
 	DeambiguationSquare // Full square disambiguation (e.g., e8 in Qe8f7)
+	RawMove             // A whole move in another notation (e.g., P-K4), see WithRawMoves
 )
 
 func (t TokenType) String() string {
@@ -96,6 +99,8 @@ func (t TokenType) String() string {
 		"CommandName",
 		"CommandParam",
 		"CommandEnd",
+		"DeambiguationSquare",
+		"RawMove",
 	}
 
 	if t < 0 || int(t) >= len(types) {
@@ -107,9 +112,12 @@ func (t TokenType) String() string {
 
 // Token represents a lexical token from PGN text.
 type Token struct {
-	Error error
-	Value string
-	Type  TokenType
+	Error  error
+	Value  string
+	Type   TokenType
+	Offset int // Byte offset of the token in the input
+	Line   int // Line of the token, starting at 1
+	Column int // Column of the token in runes, starting at 1
 }
 
 // Lexer provides lexical analysis of PGN text.
@@ -122,6 +130,11 @@ type Lexer struct {
 	inComment      bool
 	inCommand      bool
 	inCommandParam bool
+	rawMoves       bool
+	// location of locOffset, used to compute the line and column of tokens
+	locOffset int
+	line      int
+	column    int
 }
 
 // NewLexer creates a new Lexer for the provided input text.
@@ -131,12 +144,35 @@ type Lexer struct {
 // Example:
 //
 //	lexer := NewLexer("1. e4 e5")
-func NewLexer(input string) *Lexer {
-	l := &Lexer{input: input}
+func NewLexer(input string, opts ...LexerOption) *Lexer {
+	l := &Lexer{input: input, line: 1, column: 1}
+	for _, opt := range opts {
+		opt(l)
+	}
 	l.readChar()
 	return l
 }
 
+// LexerOption configures a Lexer.
+type LexerOption func(*Lexer)
+
+// WithRawMoves makes the lexer read each move of the movetext as a single
+// RawMove token instead of splitting it into SAN tokens. This allows
+// movetext in other notations, such as descriptive (P-K4, QxP ch) or ICCF
+// numeric (5254), to be decoded by a Decoder. Move numbers, results,
+// comments, NAGs and variations are read as usual.
+func WithRawMoves() LexerOption {
+	return func(l *Lexer) {
+		l.rawMoves = true
+	}
+}
+
+// seek moves the lexer to the given position of the input.
+func (l *Lexer) seek(position int) {
+	l.readPosition = position
+	l.readChar()
+}
+
 func (l *Lexer) peekChar() byte {
 	if l.readPosition >= len(l.input) {
 		return 0
@@ -237,6 +273,34 @@ func (l *Lexer) readNAG() Token {
 	}
 }
 
+// readNAGSymbol reads a NAG written as a symbol accepted by ParseGlyph, such
+// as "±", "+-" or "N". The symbol must stand alone, between whitespace or
+// brackets, so that it isn't confused with a check, a promotion or a piece.
+func (l *Lexer) readNAGSymbol() (Token, bool) {
+	if l.inTag || l.ch == 0 || isNAGSymbolDelimiter(l.ch) {
+		return Token{}, false
+	}
+	if l.position > 0 && !isNAGSymbolDelimiter(l.input[l.position-1]) {
+		return Token{}, false
+	}
+	end := l.position
+	for end < len(l.input) && !isNAGSymbolDelimiter(l.input[end]) {
+		end++
+	}
+	symbol := l.input[l.position:end]
+	if _, ok := nagsBySymbol[symbol]; !ok {
+		return Token{}, false
+	}
+	for l.position < end {
+		l.readChar()
+	}
+	return Token{Type: NAG, Value: symbol}, true
+}
+
+func isNAGSymbolDelimiter(c byte) bool {
+	return c == 0 || isWhitespace(c) || strings.IndexByte("(){};$", c) >= 0
+}
+
 func (l *Lexer) readResult() Token {
 	position := l.position
 	for !isWhitespace(l.ch) && l.ch != 0 {
@@ -302,6 +366,68 @@ func (l *Lexer) readComment() Token {
 	return Token{Type: CommentEnd, Value: "}"}
 }
 
+// rawMoveSuffixes are the words that may follow a raw move, separated by a
+// space, and are part of it, such as "ch" in "QxP ch".
+//
+//nolint:gochecknoglobals // lookup table.
+var rawMoveSuffixes = []string{"ch", "dbl", "dis", "mate", "e.p.", "ep"}
+
+// isRawMoveDelimiter reports whether c ends a raw move.
+func isRawMoveDelimiter(c byte) bool {
+	return c == 0 || isWhitespace(c) || strings.IndexByte("()[]{}\"$!?*;", c) >= 0
+}
+
+// readRawMove reads a move of another notation as a single token. A word
+// made of digits followed by a dot is a move number and a word matching a
+// result is a result.
+func (l *Lexer) readRawMove() Token {
+	position := l.position
+	for {
+		switch {
+		case l.ch == '(':
+			// a parenthesized qualifier inside the move, e.g. R(1)-Q1
+			end := l.position + 1
+			for end < len(l.input) && l.input[end] != ')' && !isRawMoveDelimiter(l.input[end]) {
+				end++
+			}
+			if l.position == position || end >= len(l.input) || l.input[end] != ')' {
+				return l.rawMoveToken(position)
+			}
+			l.seek(end + 1)
+		case l.ch == '.' && isDigits(l.input[position:l.position]):
+			return Token{Type: MoveNumber, Value: l.input[position:l.position]}
+		case isRawMoveDelimiter(l.ch):
+			return l.rawMoveToken(position)
+		default:
+			l.readChar()
+		}
+	}
+}
+
+// rawMoveToken returns the token of the word from position to the current
+// position, followed by any suffix words.
+func (l *Lexer) rawMoveToken(position int) Token {
+	word := l.input[position:l.position]
+	if isResult(word) {
+		return Token{Type: RESULT, Value: word}
+	}
+	for {
+		start := l.position
+		for start < len(l.input) && (l.input[start] == ' ' || l.input[start] == '\t') {
+			start++
+		}
+		end := start
+		for end < len(l.input) && !isRawMoveDelimiter(l.input[end]) {
+			end++
+		}
+		if start == l.position || !slices.Contains(rawMoveSuffixes, l.input[start:end]) {
+			break
+		}
+		l.seek(end)
+	}
+	return Token{Type: RawMove, Value: strings.Join(strings.Fields(l.input[position:l.position]), " ")}
+}
+
 // Update readPieceMove to handle piece moves.
 func (l *Lexer) readPieceMove() Token {
 	// Capture just the piece
@@ -467,6 +593,8 @@ func (l *Lexer) readCastling() (Token, bool) {
 // - Move numbers and variations
 // - Annotations ($1, !!, ?!)
 //
+// Each token records its byte offset, line and column in the input.
+//
 // Example:
 //
 //	lexer := NewLexer("1. e4 {Strong move}")
@@ -478,6 +606,31 @@ func (l *Lexer) readCastling() (Token, bool) {
 func (l *Lexer) NextToken() Token {
 	l.skipWhitespace()
 
+	offset := min(l.position, len(l.input))
+	l.advanceLocation(offset)
+	tok := l.nextToken()
+	tok.Offset = offset
+	tok.Line = l.line
+	tok.Column = l.column
+	return tok
+}
+
+// advanceLocation moves the tracked line and column forward to offset.
+// Offsets only grow between tokens, so the input is scanned once.
+func (l *Lexer) advanceLocation(offset int) {
+	for ; l.locOffset < offset; l.locOffset++ {
+		switch c := l.input[l.locOffset]; {
+		case c == '\n':
+			l.line++
+			l.column = 1
+		case utf8.RuneStart(c):
+			l.column++
+		}
+	}
+}
+
+// nextToken reads the token starting at the current position.
+func (l *Lexer) nextToken() Token {
 	if l.inCommand {
 		switch l.ch {
 		case ']':
@@ -509,6 +662,14 @@ func (l *Lexer) NextToken() Token {
 		return l.readTagKey()
 	}
 
+	if tok, ok := l.readNAGSymbol(); ok {
+		return tok
+	}
+
+	if l.rawMoves && !l.inTag && l.ch != '.' && !isRawMoveDelimiter(l.ch) {
+		return l.readRawMove()
+	}
+
 	switch l.ch {
 	case '(':
 		l.readChar()
@@ -587,7 +748,7 @@ func (l *Lexer) NextToken() Token {
 		switch l.ch {
 		case '.':
 			return Token{Type: MoveNumber, Value: l.input[position:l.position]}
-		case '-':
+		case '-', '/':
 			l.position = position
 			l.readPosition = position + 1
 			l.ch = l.input[position]
@@ -314,6 +314,28 @@ func TestNAG(t *testing.T) {
 				{Type: NAG, Value: "$3"},
 			},
 		},
+		{
+			name:  "NAG symbols",
+			input: "e4! ± e5 +- Nf3 = N exf8=Q+ -+",
+			expected: []Token{
+				{Type: SQUARE, Value: "e4"},
+				{Type: NAG, Value: "!"},
+				{Type: NAG, Value: "±"},
+				{Type: SQUARE, Value: "e5"},
+				{Type: NAG, Value: "+-"},
+				{Type: PIECE, Value: "N"},
+				{Type: SQUARE, Value: "f3"},
+				{Type: NAG, Value: "="},
+				{Type: NAG, Value: "N"},
+				{Type: FILE, Value: "e"},
+				{Type: CAPTURE, Value: "x"},
+				{Type: SQUARE, Value: "f8"},
+				{Type: PROMOTION, Value: "="},
+				{Type: PromotionPiece, Value: "Q"},
+				{Type: CHECK, Value: "+"},
+				{Type: NAG, Value: "-+"},
+			},
+		},
 		{
 			name:  "NAG and comment after move",
 			input: "e4 $1 {Good move}",
@@ -1132,3 +1154,82 @@ func TestSingleFromPosPGN(t *testing.T) {
 		t.Errorf("Expected EOF, got %v", tok.Type)
 	}
 }
+
+func TestLexerTokenLocation(t *testing.T) {
+	input := "[Event \"Café\"]\n\n1. e4 {née} e5\n2. Nf3"
+	expected := []struct {
+		value        string
+		offset       int
+		line, column int
+	}{
+		{"[", 0, 1, 1},
+		{"Event", 1, 1, 2},
+		{"Café", 7, 1, 8},
+		{"]", 14, 1, 14},
+		{"1", 17, 3, 1},
+		{".", 18, 3, 2},
+		{"e4", 20, 3, 4},
+		{"{", 23, 3, 7},
+		{"née", 24, 3, 8},
+		{"}", 28, 3, 11},
+		{"e5", 30, 3, 13},
+		{"2", 33, 4, 1},
+		{".", 34, 4, 2},
+		{"N", 36, 4, 4},
+	}
+
+	lexer := NewLexer(input)
+	for i, exp := range expected {
+		tok := lexer.NextToken()
+		if tok.Value != exp.value || tok.Offset != exp.offset || tok.Line != exp.line || tok.Column != exp.column {
+			t.Errorf("Token %d - Expected {%q, %d, %d:%d}, got {%q, %d, %d:%d}",
+				i, exp.value, exp.offset, exp.line, exp.column, tok.Value, tok.Offset, tok.Line, tok.Column)
+		}
+	}
+}
+
+func TestLexerRawMoves(t *testing.T) {
+	input := `[Event "Old"] 1. P-K4 P-K4 2. Kt-KB3 R(1)-Q1 {book} 3... PxP e.p. $1 ` +
+		`4. QxP ch! (4. P-Q8(Q) dis ch) 5254 1/2-1/2`
+	expected := []Token{
+		{Type: TagStart, Value: "["},
+		{Type: TagKey, Value: "Event"},
+		{Type: TagValue, Value: "Old"},
+		{Type: TagEnd, Value: "]"},
+		{Type: MoveNumber, Value: "1"},
+		{Type: DOT, Value: "."},
+		{Type: RawMove, Value: "P-K4"},
+		{Type: RawMove, Value: "P-K4"},
+		{Type: MoveNumber, Value: "2"},
+		{Type: DOT, Value: "."},
+		{Type: RawMove, Value: "Kt-KB3"},
+		{Type: RawMove, Value: "R(1)-Q1"},
+		{Type: CommentStart, Value: "{"},
+		{Type: COMMENT, Value: "book"},
+		{Type: CommentEnd, Value: "}"},
+		{Type: MoveNumber, Value: "3"},
+		{Type: ELLIPSIS, Value: "..."},
+		{Type: RawMove, Value: "PxP e.p."},
+		{Type: NAG, Value: "$1"},
+		{Type: MoveNumber, Value: "4"},
+		{Type: DOT, Value: "."},
+		{Type: RawMove, Value: "QxP ch"},
+		{Type: NAG, Value: "!"},
+		{Type: VariationStart, Value: "("},
+		{Type: MoveNumber, Value: "4"},
+		{Type: DOT, Value: "."},
+		{Type: RawMove, Value: "P-Q8(Q) dis ch"},
+		{Type: VariationEnd, Value: ")"},
+		{Type: RawMove, Value: "5254"},
+		{Type: RESULT, Value: "1/2-1/2"},
+		{Type: EOF, Value: ""},
+	}
+
+	lexer := NewLexer(input, WithRawMoves())
+	for i, exp := range expected {
+		tok := lexer.NextToken()
+		if tok.Type != exp.Type || tok.Value != exp.Value {
+			t.Fatalf("Token %d - Expected %v %q, got %v %q", i, exp.Type, exp.Value, tok.Type, tok.Value)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package chess
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 // A MoveTag represents a notable consequence of a move.
 type MoveTag uint16
@@ -25,7 +28,7 @@ const (
 type Move struct {
 	parent   *Move
 	position *Position // Position after the move
-	nag      string
+	nags     []string  // NAGs as written, e.g. "$1" or "!?"
 	comments string
 	command  map[string]string // Store commands as key-value pairs
 	children []*Move           // Main line and variations
@@ -99,12 +102,54 @@ func (m *Move) Comments() string {
 	return m.comments
 }
 
+// NAG returns the first Numeric Annotation Glyph of the move as written,
+// e.g. "$1" or "!?", or an empty string if it has none. It is the string
+// form of the first glyph returned by NAGs when that one is valid.
+//
+// Deprecated: use NAGs, which returns every glyph of the move as a Glyph.
 func (m *Move) NAG() string {
-	return m.nag
+	if len(m.nags) == 0 {
+		return ""
+	}
+	return m.nags[0]
 }
 
+// SetNAG replaces the Numeric Annotation Glyphs of the move with nag, which
+// is written as is. An empty nag removes them. SetNAG(g.String()) is
+// equivalent to SetNAGs(g).
+//
+// Deprecated: use SetNAGs or AddNAG, which take a Glyph.
 func (m *Move) SetNAG(nag string) {
-	m.nag = nag
+	m.nags = nil
+	if nag != "" {
+		m.nags = []string{nag}
+	}
+}
+
+// NAGs returns the Numeric Annotation Glyphs of the move, in order.
+// Glyphs that don't parse with ParseGlyph are skipped.
+func (m *Move) NAGs() []Glyph {
+	nags := make([]Glyph, 0, len(m.nags))
+	for _, s := range m.nags {
+		if n, err := ParseGlyph(s); err == nil {
+			nags = append(nags, n)
+		}
+	}
+	return nags
+}
+
+// SetNAGs replaces the Numeric Annotation Glyphs of the move with nags.
+// Calling it without glyphs removes them.
+func (m *Move) SetNAGs(nags ...Glyph) {
+	m.nags = nil
+	for _, g := range nags {
+		m.AddNAG(g)
+	}
+}
+
+// AddNAG appends a Numeric Annotation Glyph to the move.
+func (m *Move) AddNAG(g Glyph) {
+	m.nags = append(m.nags, g.String())
 }
 
 func (m *Move) Parent() *Move {
@@ -161,7 +206,7 @@ func (m *Move) Clone() *Move {
 	ret := &Move{}
 	ret.parent = nil
 	ret.position = m.position.copy()
-	ret.nag = m.nag
+	ret.nags = slices.Clone(m.nags)
 	ret.comments = m.comments
 	ret.children = make([]*Move, 0)
 	ret.number = m.number
@@ -190,3 +235,48 @@ func (m *Move) cloneChildren(srcChildren []*Move) {
 		m.children = append(m.children, dstMv)
 	}
 }
+
+// pathTo returns the child indexes leading from the root of the move tree
+// to m.
+func pathTo(m *Move) []int {
+	var path []int
+	for ; m.parent != nil; m = m.parent {
+		path = append(path, slices.Index(m.parent.children, m))
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// follow returns the move reached from m by following the child indexes
+// of path, or nil if there is none.
+func (m *Move) follow(path []int) *Move {
+	for _, i := range path {
+		if i < 0 || i >= len(m.children) {
+			return nil
+		}
+		m = m.children[i]
+	}
+	return m
+}
+
+// appendChild attaches m as the last child of parent, computing the
+// position after the move and its move number. The move must be legal
+// in the position of parent.
+func (m *Move) appendChild(child *Move) *Move {
+	child.parent = m
+	child.number = uint(m.position.moveCount)
+	child.position = m.position.Update(child)
+	m.children = append(m.children, child)
+	return child
+}
+
+// legalMove returns the legal move of pos going from s1 to s2 with the
+// given promotion, or nil if there is none.
+func legalMove(pos *Position, s1, s2 Square, promo PieceType) *Move {
+	for _, m := range pos.ValidMoves() {
+		if m.s1 == s1 && m.s2 == s2 && m.promo == promo {
+			return &m
+		}
+	}
+	return nil
+}
@@ -369,7 +369,7 @@ func TestAddComment(t *testing.T) {
 
 func TestNAGReturnsCorrectValue(t *testing.T) {
 	t.Run("NAGReturnsCorrectValue", func(t *testing.T) {
-		move := &Move{nag: "!!"}
+		move := &Move{nags: []string{"!!"}}
 		expected := "!!"
 		if move.NAG() != expected {
 			t.Fatalf("expected %v but got %v", expected, move.NAG())
@@ -447,7 +447,7 @@ func assertMovesAreEqual(t *testing.T, m1, m2 *Move) {
 	if m1.position.String() != m2.position.String() {
 		t.Fatalf("cloned mv %s position is not the same", m1)
 	}
-	if m1.nag != m2.nag {
+	if m1.NAG() != m2.NAG() {
 		t.Fatalf("cloned mv %s nag is not the same", m1)
 	}
 	if m1.comments != m2.comments {
@@ -182,7 +182,14 @@ func (UCINotation) Decode(pos *Position, s string) (*Move, error) {
 // AlgebraicNotation (or Standard Algebraic Notation) is the
 // official chess notation used by FIDE. Examples: e4, e5,
 // O-O (short castling), e8=Q (promotion).
-type AlgebraicNotation struct{}
+type AlgebraicNotation struct {
+	// Lenient accepts the variants commonly typed by humans when decoding:
+	// castling with zeros, promotions written e8Q, e8(Q) or e8/Q, a pawn
+	// letter as in Pe4, superfluous disambiguation as in Ngf3, a missing
+	// or extra capture sign, e.p. suffixes and lowercase piece letters
+	// other than b. A promotion piece is still required.
+	Lenient bool
+}
 
 // String implements the fmt.Stringer interface and returns
 // the notation's name.
@@ -341,7 +348,13 @@ func (mc moveComponents) generateOptions() []string {
 }
 
 // Decode implements the Decoder interface.
-func (AlgebraicNotation) Decode(pos *Position, s string) (*Move, error) {
+// Moves that can't be matched to a legal move are reported with a
+// *MoveDecodeError.
+func (n AlgebraicNotation) Decode(pos *Position, s string) (*Move, error) {
+	if n.Lenient {
+		return decodeLenientSAN(pos, s)
+	}
+
 	// Parse move components
 	components, err := algebraicNotationParts(s)
 	if err != nil {
@@ -375,7 +388,11 @@ func (AlgebraicNotation) Decode(pos *Position, s string) (*Move, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("chess: move %s is not valid", s)
+	// Tell why the move is not valid, a lenient match is still an error
+	if _, err := decodeLenientSAN(pos, s); err != nil {
+		return nil, err
+	}
+	return nil, &MoveDecodeError{Err: ErrNoSuchMove, Move: s}
 }
 
 // LongAlgebraicNotation is a fully expanded version of
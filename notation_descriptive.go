@@ -0,0 +1,460 @@
+package chess
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DescriptiveNotation is the English descriptive notation found in chess
+// literature before the 1980s. Files are named after the pieces standing on
+// them at the start (QR, QN, QB, Q, K, KB, KN, KR) and ranks are counted
+// from the side of the moving player. Examples: P-K4, N-KB3, QxP, PxP e.p.,
+// P-K8=Q, R/1-Q1, O-O.
+//
+// Decode accepts the usual variants: Kt for the knight, check and mate
+// written ch or mate, promotions written P-K8(Q), and pieces or squares
+// qualified with a slash or parentheses, e.g. N/K5xP or R(1)-Q1. A piece
+// prefixed with its wing, such as KR or QN, designates the piece standing
+// on that half of the board, and a pawn prefixed with a file, such as KP
+// or QBP, the pawn on that file. Encode writes the shortest unambiguous
+// form, with check and mate written + and #.
+type DescriptiveNotation struct{}
+
+// String implements the fmt.Stringer interface and returns
+// the notation's name.
+func (DescriptiveNotation) String() string {
+	return "Descriptive Notation"
+}
+
+//nolint:gochecknoglobals // lookup table.
+var descriptiveFiles = [8]string{"QR", "QN", "QB", "Q", "K", "KB", "KN", "KR"}
+
+// descriptiveRank returns the rank of sq counted from the side of c.
+func descriptiveRank(sq Square, c Color) int {
+	if c == Black {
+		return 8 - int(sq.Rank())
+	}
+	return int(sq.Rank()) + 1
+}
+
+// descSquares designates squares: a set of files and, optionally, a rank
+// counted from the side of the moving player.
+type descSquares struct {
+	files uint8 // bit f set for each file f
+	rank  int   // 1 to 8, 0 for any
+}
+
+func (d descSquares) match(sq Square, c Color) bool {
+	return d.files&(1<<sq.File()) != 0 && (d.rank == 0 || d.rank == descriptiveRank(sq, c))
+}
+
+// descPiece designates a piece by its type, the files it may stand on and
+// an optional qualifying set of squares.
+type descPiece struct {
+	typ       PieceType
+	files     uint8 // bit f set for each file f
+	qualified bool
+	qualifier descSquares
+}
+
+func (d descPiece) match(p Piece, sq Square, c Color) bool {
+	return p.Type() == d.typ && d.files&(1<<sq.File()) != 0 &&
+		(!d.qualified || d.qualifier.match(sq, c))
+}
+
+// descMove is a parsed descriptive move.
+type descMove struct {
+	castle   MoveTag // KingSideCastle, QueenSideCastle, both for "Castles", or 0
+	piece    descPiece
+	capture  bool
+	target   descSquares // destination of a move
+	captured descPiece   // piece taken by a capture
+	promo    PieceType
+}
+
+// match reports whether m, played by c in pos, is described by d.
+func (d descMove) match(pos *Position, m *Move, c Color) bool {
+	castle := m.tags & (KingSideCastle | QueenSideCastle)
+	if d.castle != 0 || castle != 0 {
+		return d.castle&castle != 0
+	}
+	board := pos.Board()
+	if !d.piece.match(board.Piece(m.s1), m.s1, c) {
+		return false
+	}
+	isCapture := m.HasTag(Capture) || m.HasTag(EnPassant)
+	if d.capture != isCapture {
+		return false
+	}
+	if d.capture {
+		victim, at := board.Piece(m.s2), m.s2
+		if m.HasTag(EnPassant) {
+			at = NewSquare(m.s2.File(), m.s1.Rank())
+			victim = board.Piece(at)
+		}
+		if !d.captured.match(victim, at, c) {
+			return false
+		}
+	} else if !d.target.match(m.s2, c) {
+		return false
+	}
+	if d.promo == NoPieceType {
+		return m.promo == NoPieceType || m.promo == Queen
+	}
+	return m.promo == d.promo
+}
+
+// Encode implements the Encoder interface.
+func (DescriptiveNotation) Encode(pos *Position, m *Move) string {
+	check := getCheckChar(pos, m)
+	if m.HasTag(KingSideCastle) {
+		return "O-O" + check
+	}
+	if m.HasTag(QueenSideCastle) {
+		return "O-O-O" + check
+	}
+
+	c := pos.Turn()
+	board := pos.Board()
+	p := board.Piece(m.s1)
+	movers := descriptivePieceNames(p, m.s1, c)
+	var targets []string
+	if m.HasTag(Capture) || m.HasTag(EnPassant) {
+		victim, at := board.Piece(m.s2), m.s2
+		if m.HasTag(EnPassant) {
+			at = NewSquare(m.s2.File(), m.s1.Rank())
+			victim = board.Piece(at)
+		}
+		for _, name := range descriptivePieceNames(victim, at, c) {
+			targets = append(targets, "x"+name)
+		}
+	} else {
+		for _, name := range descriptiveSquareNames(m.s2, c) {
+			targets = append(targets, "-"+name)
+		}
+	}
+	promo := ""
+	if m.promo != NoPieceType {
+		promo = "=" + charFromPieceType(m.promo)
+	}
+
+	var candidates []string
+	for _, mover := range movers {
+		for _, target := range targets {
+			candidates = append(candidates, mover+target+promo)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b string) int { return cmp.Compare(len(a), len(b)) })
+	for _, s := range candidates {
+		if d, err := parseDescriptive(s); err == nil && d.unique(pos, m, c) {
+			return s + check
+		}
+	}
+	// Unreachable for legal moves: the fully qualified form is unique.
+	return candidates[len(candidates)-1] + check
+}
+
+// unique reports whether m is the only valid move of pos described by d.
+func (d descMove) unique(pos *Position, m *Move, c Color) bool {
+	found := false
+	for _, mv := range pos.ValidMoves() {
+		if d.match(pos, &mv, c) {
+			if mv.s1 != m.s1 || mv.s2 != m.s2 || mv.promo != m.promo {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// descriptivePieceNames returns the names of the piece p on sq, from the
+// shortest to the fully qualified one.
+func descriptivePieceNames(p Piece, sq Square, c Color) []string {
+	letter := charFromPieceType(p.Type())
+	if p.Type() == Pawn {
+		letter = "P"
+	}
+	file := descriptiveFiles[sq.File()]
+	full := file + strconv.Itoa(descriptiveRank(sq, c))
+	names := []string{letter}
+	switch p.Type() {
+	case Pawn:
+		names = append(names, file[len(file)-1:]+letter, file+letter)
+	case Rook, Knight, Bishop:
+		names = append(names, file[:1]+letter)
+	}
+	return append(names, letter+"/"+strconv.Itoa(descriptiveRank(sq, c)), letter+"/"+full)
+}
+
+// descriptiveSquareNames returns the names of sq, from the shortest to the
+// fully qualified one.
+func descriptiveSquareNames(sq Square, c Color) []string {
+	file := descriptiveFiles[sq.File()]
+	rank := strconv.Itoa(descriptiveRank(sq, c))
+	if len(file) == 1 {
+		return []string{file + rank}
+	}
+	return []string{file[1:] + rank, file + rank}
+}
+
+// Decode implements the Decoder interface.
+func (DescriptiveNotation) Decode(pos *Position, s string) (*Move, error) {
+	d, err := parseDescriptive(s)
+	if err != nil {
+		return nil, err
+	}
+	c := pos.Turn()
+	var found *Move
+	for _, m := range pos.ValidMoves() {
+		if !d.match(pos, &m, c) {
+			continue
+		}
+		if found != nil && (found.s1 != m.s1 || found.s2 != m.s2) {
+			return nil, fmt.Errorf("chess: descriptive move %q is ambiguous", s)
+		}
+		if found == nil {
+			found = &m
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("chess: descriptive move %q is not valid", s)
+	}
+	return found, nil
+}
+
+// descriptiveSuffixes are the annotations that may follow a descriptive
+// move, attached or separated by a space.
+//
+//nolint:gochecknoglobals // lookup table.
+var descriptiveSuffixes = []string{"ch", "dbl", "dis", "mate", "e.p.", "ep", "+", "#", "!", "?"}
+
+// parseDescriptive parses a descriptive move.
+func parseDescriptive(s string) (descMove, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return descMove{}, fmt.Errorf("chess: invalid descriptive notation %q", s)
+	}
+	for _, f := range fields[1:] {
+		if !slices.Contains(descriptiveSuffixes, f) {
+			return descMove{}, fmt.Errorf("chess: invalid descriptive notation %q", s)
+		}
+	}
+	text := fields[0]
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, suffix := range descriptiveSuffixes {
+			if len(text) > len(suffix) && strings.HasSuffix(text, suffix) {
+				text = strings.TrimSuffix(text, suffix)
+				trimmed = true
+			}
+		}
+	}
+
+	switch text {
+	case "O-O", "0-0":
+		return descMove{castle: KingSideCastle}, nil
+	case "O-O-O", "0-0-0":
+		return descMove{castle: QueenSideCastle}, nil
+	case "Castles":
+		if len(fields) == 1 {
+			return descMove{castle: KingSideCastle | QueenSideCastle}, nil
+		}
+	}
+
+	p := &descParser{s: text}
+	d, ok := p.move()
+	if !ok || p.s != "" {
+		return descMove{}, fmt.Errorf("chess: invalid descriptive notation %q", s)
+	}
+	return d, nil
+}
+
+// descParser is a recursive descent parser over the text of a move.
+type descParser struct {
+	s string
+}
+
+func (p *descParser) accept(prefix string) bool {
+	if strings.HasPrefix(p.s, prefix) {
+		p.s = p.s[len(prefix):]
+		return true
+	}
+	return false
+}
+
+// letter reads a piece letter.
+func (p *descParser) letter() (PieceType, bool) {
+	if p.accept("Kt") {
+		return Knight, true
+	}
+	for _, pt := range []PieceType{King, Queen, Rook, Bishop, Knight, Pawn} {
+		letter := charFromPieceType(pt)
+		if pt == Pawn {
+			letter = "P"
+		}
+		if p.accept(letter) {
+			return pt, true
+		}
+	}
+	return NoPieceType, false
+}
+
+// descFiles returns the files designated by an optional wing and an
+// optional piece letter, e.g. K, KB or B.
+func descFiles(wing, piece PieceType) uint8 {
+	var files uint8
+	switch piece {
+	case Rook:
+		files = 1<<0 | 1<<7
+	case Knight:
+		files = 1<<1 | 1<<6
+	case Bishop:
+		files = 1<<2 | 1<<5
+	case NoPieceType:
+		switch wing {
+		case King:
+			return 1 << 4
+		case Queen:
+			return 1 << 3
+		}
+		return 0xFF
+	}
+	switch wing {
+	case King:
+		files &= 0xF0
+	case Queen:
+		files &= 0x0F
+	}
+	return files
+}
+
+// move parses: piece ( "-" squares | "x" piece ) [promotion].
+func (p *descParser) move() (descMove, bool) {
+	var d descMove
+	var ok bool
+	if d.piece, ok = p.piece(); !ok {
+		return d, false
+	}
+	switch {
+	case p.accept("-"):
+		if d.target, ok = p.squares(); !ok || d.target.rank == 0 {
+			return d, false
+		}
+	case p.accept("x"), p.accept(":"):
+		d.capture = true
+		if d.captured, ok = p.piece(); !ok {
+			return d, false
+		}
+	default:
+		return d, false
+	}
+	if p.s != "" {
+		for _, open := range []string{"=", "(", "/", ""} {
+			rest := p.s
+			if !p.accept(open) {
+				continue
+			}
+			pt, ok := p.letter()
+			if ok && pt != King && pt != Pawn && (open != "(" || p.accept(")")) {
+				d.promo = pt
+				break
+			}
+			p.s = rest
+		}
+	}
+	return d, true
+}
+
+// piece parses: [wing] [file piece] letter [qualifier]. A piece letter
+// preceded by K or Q is qualified by its wing; a pawn may be preceded by
+// the description of its file.
+func (p *descParser) piece() (descPiece, bool) {
+	var letters []PieceType
+	for len(letters) < 3 {
+		pt, ok := p.letter()
+		if !ok {
+			break
+		}
+		letters = append(letters, pt)
+	}
+	if len(letters) == 0 {
+		return descPiece{}, false
+	}
+	d := descPiece{typ: letters[len(letters)-1]}
+	prefix := letters[:len(letters)-1]
+	switch {
+	case len(prefix) == 0:
+		d.files = 0xFF
+	case d.typ == Pawn && len(prefix) == 1 && prefix[0] != Pawn:
+		if prefix[0] == King || prefix[0] == Queen {
+			d.files = descFiles(prefix[0], NoPieceType)
+		} else {
+			d.files = descFiles(NoPieceType, prefix[0])
+		}
+	case d.typ == Pawn && len(prefix) == 2 && isWing(prefix[0]) && isFilePiece(prefix[1]):
+		d.files = descFiles(prefix[0], prefix[1])
+	case isFilePiece(d.typ) && len(prefix) == 1 && prefix[0] == King:
+		d.files = 0xF0 // king side
+	case isFilePiece(d.typ) && len(prefix) == 1 && prefix[0] == Queen:
+		d.files = 0x0F // queen side
+	default:
+		return descPiece{}, false
+	}
+
+	// A qualifier must have a rank, telling it apart from a promotion.
+	rest := p.s
+	if p.accept("/") || p.accept("(") {
+		q, ok := p.squares()
+		if ok && q.rank != 0 && (rest[0] == '/' || p.accept(")")) {
+			d.qualified, d.qualifier = true, q
+		} else {
+			p.s = rest
+		}
+	}
+	return d, true
+}
+
+// squares parses: [wing] [file piece] [rank], with at least one part.
+func (p *descParser) squares() (descSquares, bool) {
+	wing, piece := NoPieceType, NoPieceType
+	rest := p.s
+	if pt, ok := p.letter(); ok {
+		switch {
+		case isWing(pt):
+			wing = pt
+			afterWing := p.s
+			if next, ok := p.letter(); ok {
+				if isFilePiece(next) {
+					piece = next
+				} else {
+					p.s = afterWing // a promotion piece, as in P-K8Q
+				}
+			}
+		case isFilePiece(pt):
+			piece = pt
+		default:
+			p.s = rest
+			return descSquares{}, false
+		}
+	}
+	d := descSquares{files: descFiles(wing, piece)}
+	if p.s != "" && p.s[0] >= '1' && p.s[0] <= '8' {
+		d.rank = int(p.s[0] - '0')
+		p.s = p.s[1:]
+	} else if wing == NoPieceType && piece == NoPieceType {
+		return descSquares{}, false
+	}
+	return d, true
+}
+
+func isWing(pt PieceType) bool {
+	return pt == King || pt == Queen
+}
+
+func isFilePiece(pt PieceType) bool {
+	return pt == Rook || pt == Knight || pt == Bishop
+}
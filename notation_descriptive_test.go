@@ -0,0 +1,94 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestDescriptiveNotation(t *testing.T) {
+	tests := []struct {
+		fen      string
+		uci      string
+		expected string
+	}{
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e2e4", "P-K4"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "g1f3", "N-KB3"},
+		{"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", "e7e5", "P-K4"},
+		{"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", "b8c6", "N-QB3"},
+		{"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1", "e5d6", "PxP"},
+		{"4k3/8/8/3p1p2/8/4N3/8/4K3 w - - 0 1", "e3d5", "NxQP"},
+		{"4k3/8/8/3p4/2P1P3/8/8/4K3 w - - 0 1", "c4d5", "BPxP"},
+		{"4k3/8/8/8/R7/8/8/R3K3 w - - 0 1", "a1a2", "R/1-R2"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "e1g1", "O-O"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "e1c1", "O-O-O"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "a1b1", "R-QN1"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "h8f8", "R-KB1"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "a8a1", "RxQR+"},
+		{"8/3P4/8/8/8/8/8/k6K w - - 0 1", "d7d8q", "P-Q8=Q"},
+		{"8/3P4/8/8/8/8/8/k6K w - - 0 1", "d7d8n", "P-Q8=N"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fen+" "+tt.uci, func(t *testing.T) {
+			pos := unsafeFEN(tt.fen)
+			m, err := UCINotation{}.Decode(pos, tt.uci)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := (DescriptiveNotation{}).Encode(pos, m); got != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+			decoded, err := DescriptiveNotation{}.Decode(pos, tt.expected)
+			if err != nil {
+				t.Fatalf("decoding %s: %v", tt.expected, err)
+			}
+			if decoded.String() != m.String() {
+				t.Fatalf("expected %s to decode to %s, got %s", tt.expected, m, decoded)
+			}
+		})
+	}
+}
+
+func TestDescriptiveNotationDecode(t *testing.T) {
+	tests := []struct {
+		fen      string
+		move     string
+		expected string // UCI, or empty when the move is invalid
+	}{
+		{"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1", "PxP e.p.", "e5d6"},
+		{"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1", "PxPep", "e5d6"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "Kt-KB3", "g1f3"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "KP-K4", "e2e4"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "P-QB4!", "c2c4"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "N-B3", ""},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "P-K5", ""},
+		{"4k3/8/8/3p1p2/8/4N3/8/4K3 w - - 0 1", "NxP", ""},
+		{"4k3/8/8/3p1p2/8/4N3/8/4K3 w - - 0 1", "NxKBP ch", "e3f5"},
+		{"4k3/8/8/8/R7/8/8/R3K3 w - - 0 1", "R(4)-R2", "a4a2"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w Kkq - 0 1", "Castles", "e1g1"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "Castles", ""},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "0-0-0", "e1c1"},
+		{"8/3P4/8/8/8/8/8/k6K w - - 0 1", "P-Q8", "d7d8q"},
+		{"8/3P4/8/8/8/8/8/k6K w - - 0 1", "P-Q8(R)", "d7d8r"},
+		{"8/3P4/8/8/8/8/8/k6K w - - 0 1", "P-Q8B", "d7d8b"},
+		{"8/3P4/8/8/8/8/8/k6K w - - 0 1", "P-Q8=K", ""},
+		{"8/3P4/8/8/8/8/8/k6K w - - 0 1", "e4", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.move, func(t *testing.T) {
+			m, err := DescriptiveNotation{}.Decode(unsafeFEN(tt.fen), tt.move)
+			if tt.expected == "" {
+				if err == nil {
+					t.Fatalf("expected %q to be invalid, got %s", tt.move, m)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.String() != tt.expected {
+				t.Fatalf("expected %q to decode to %s, got %s", tt.move, tt.expected, m)
+			}
+		})
+	}
+}
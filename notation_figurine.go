@@ -0,0 +1,56 @@
+package chess
+
+import (
+	"strings"
+)
+
+// FigurineNotation (Figurine Algebraic Notation, FAN) is Standard Algebraic
+// Notation with the Unicode chess glyphs in place of the piece letters.
+// Examples: ♘f3, ♛xd8+, e8=♕. The glyphs match the color of the moving
+// side unless Neutral is set.
+type FigurineNotation struct {
+	// Neutral writes the white glyphs for both sides, as in most printed
+	// books.
+	Neutral bool
+}
+
+// String implements the fmt.Stringer interface and returns
+// the notation's name.
+func (FigurineNotation) String() string {
+	return "Figurine Algebraic Notation"
+}
+
+// figurines returns the table of the glyphs of the color.
+func figurines(c Color) Lang {
+	return Lang{
+		Name:   "Figurine",
+		King:   NewPiece(King, c).String(),
+		Queen:  NewPiece(Queen, c).String(),
+		Rook:   NewPiece(Rook, c).String(),
+		Bishop: NewPiece(Bishop, c).String(),
+		Knight: NewPiece(Knight, c).String(),
+	}
+}
+
+// Encode implements the Encoder interface.
+func (n FigurineNotation) Encode(pos *Position, m *Move) string {
+	c := White
+	if !n.Neutral {
+		c = pos.Turn()
+	}
+	return translateSAN(AlgebraicNotation{}.Encode(pos, m), LangEnglish, figurines(c))
+}
+
+// figurineLetters replaces the glyphs of both colors with English letters.
+//
+//nolint:gochecknoglobals // lookup table.
+var figurineLetters = strings.NewReplacer(
+	"♔", "K", "♕", "Q", "♖", "R", "♗", "B", "♘", "N",
+	"♚", "K", "♛", "Q", "♜", "R", "♝", "B", "♞", "N",
+)
+
+// Decode implements the Decoder interface. Glyphs of either color and
+// English piece letters are accepted.
+func (FigurineNotation) Decode(pos *Position, s string) (*Move, error) {
+	return AlgebraicNotation{}.Decode(pos, figurineLetters.Replace(s))
+}
@@ -0,0 +1,48 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestFigurineNotation(t *testing.T) {
+	white := unsafeFEN("3k4/1P6/8/8/8/8/8/2Q1K1N1 w - - 0 1")
+	black := unsafeFEN("3k4/1P6/8/8/8/8/q7/2Q1K1N1 b - - 0 1")
+	tests := []struct {
+		n        FigurineNotation
+		pos      *Position
+		uci      string
+		expected string
+	}{
+		{FigurineNotation{}, white, "g1f3", "♘f3"},
+		{FigurineNotation{}, white, "b7b8q", "b8=♕+"},
+		{FigurineNotation{}, black, "a2d2", "♛d2+"},
+		{FigurineNotation{}, black, "d8e7", "♚e7"},
+		{FigurineNotation{Neutral: true}, black, "a2d2", "♕d2+"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			m, err := UCINotation{}.Decode(tt.pos, tt.uci)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.n.Encode(tt.pos, m); got != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+			decoded, err := tt.n.Decode(tt.pos, tt.expected)
+			if err != nil {
+				t.Fatalf("decoding %s: %v", tt.expected, err)
+			}
+			if decoded.String() != m.String() {
+				t.Fatalf("expected %s to decode to %s, got %s", tt.expected, m, decoded)
+			}
+		})
+	}
+
+	if _, err := (FigurineNotation{}).Decode(black, "♕b2"); err != nil {
+		t.Errorf("expected white glyphs to decode for black: %v", err)
+	}
+	if _, err := (FigurineNotation{}).Decode(black, "Qd2"); err != nil {
+		t.Errorf("expected SAN letters to decode: %v", err)
+	}
+}
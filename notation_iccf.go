@@ -0,0 +1,70 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ICCFNotation is the numeric notation of the International Correspondence
+// Chess Federation. Each square is written as its file and rank numbers,
+// from 1 to 8, and a promotion is written as a fifth digit: 1 for a queen,
+// 2 for a rook, 3 for a bishop and 4 for a knight. Castling is written as
+// the move of the king. Examples: 5254 (e2e4), 7163 (Ng1f3), 5171 (O-O),
+// 27381 (b7c8=Q).
+//
+// The promotion digits follow the ICCF standard rather than the piece
+// numbering of some software where a queen is 5, so 27385 is rejected.
+type ICCFNotation struct{}
+
+// String implements the fmt.Stringer interface and returns
+// the notation's name.
+func (ICCFNotation) String() string {
+	return "ICCF Numeric Notation"
+}
+
+// iccfPromotions are the piece types of the promotion digits.
+//
+//nolint:gochecknoglobals // lookup table.
+var iccfPromotions = [5]PieceType{NoPieceType, Queen, Rook, Bishop, Knight}
+
+// Encode implements the Encoder interface.
+func (ICCFNotation) Encode(_ *Position, m *Move) string {
+	var sb strings.Builder
+	sb.Grow(5)
+	for _, sq := range []Square{m.S1(), m.S2()} {
+		sb.WriteByte('1' + byte(sq.File()))
+		sb.WriteByte('1' + byte(sq.Rank()))
+	}
+	for digit, pt := range iccfPromotions {
+		if pt != NoPieceType && pt == m.Promo() {
+			sb.WriteByte('0' + byte(digit))
+		}
+	}
+	return sb.String()
+}
+
+// Decode implements the Decoder interface.
+func (ICCFNotation) Decode(pos *Position, s string) (*Move, error) {
+	if len(s) != 4 && len(s) != 5 {
+		return nil, fmt.Errorf("chess: invalid ICCF notation length %d in %q", len(s), s)
+	}
+	for i := range 4 {
+		if s[i] < '1' || s[i] > '8' {
+			return nil, fmt.Errorf("chess: invalid ICCF notation %q", s)
+		}
+	}
+	s1 := NewSquare(File(s[0]-'1'), Rank(s[1]-'1'))
+	s2 := NewSquare(File(s[2]-'1'), Rank(s[3]-'1'))
+	promo := NoPieceType
+	if len(s) == 5 {
+		if s[4] < '1' || s[4] > '4' {
+			return nil, fmt.Errorf("chess: invalid promotion piece in ICCF notation %q", s)
+		}
+		promo = iccfPromotions[s[4]-'0']
+	}
+	m := legalMove(pos, s1, s2, promo)
+	if m == nil {
+		return nil, fmt.Errorf("chess: ICCF move %q is not valid", s)
+	}
+	return m, nil
+}
@@ -0,0 +1,93 @@
+package chess
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestICCFNotation(t *testing.T) {
+	tests := []struct {
+		fen      string
+		uci      string
+		expected string
+	}{
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e2e4", "5254"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "g1f3", "7163"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "e1g1", "5171"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1", "e8c8", "5838"},
+		{"2r1k3/1P6/8/8/8/8/8/4K3 w - - 0 1", "b7c8q", "27381"},
+		{"2r1k3/1P6/8/8/8/8/8/4K3 w - - 0 1", "b7b8n", "27284"},
+		{"4k3/8/8/8/8/8/6p1/4K2R b - - 0 1", "g2h1r", "72812"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			pos := unsafeFEN(tt.fen)
+			m, err := UCINotation{}.Decode(pos, tt.uci)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := (ICCFNotation{}).Encode(pos, m); got != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+			decoded, err := ICCFNotation{}.Decode(pos, tt.expected)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded.String() != m.String() {
+				t.Fatalf("expected %s to decode to %s, got %s", tt.expected, m, decoded)
+			}
+		})
+	}
+}
+
+func TestICCFNotationDecodeErrors(t *testing.T) {
+	pos := unsafeFEN("2r1k3/1P6/8/8/8/8/8/4K3 w - - 0 1")
+	// 27385 is a queen promotion in some software but not in the ICCF digits
+	for _, s := range []string{"", "525", "525412", "5954", "0254", "2738", "27385", "27380", "5557", "e2e4"} {
+		if m, err := (ICCFNotation{}).Decode(pos, s); err == nil {
+			t.Errorf("expected %q to be invalid, got %s", s, m)
+		}
+	}
+}
+
+func TestICCFNotationPerftRoundTrip(t *testing.T) {
+	for _, perf := range perfResults {
+		positions := []*Position{perf.pos}
+		for _, m := range perf.pos.ValidMoves() {
+			positions = append(positions, perf.pos.Update(&m))
+		}
+		for _, pos := range positions {
+			for _, m := range pos.ValidMoves() {
+				s := ICCFNotation{}.Encode(pos, &m)
+				decoded, err := ICCFNotation{}.Decode(pos, s)
+				if err != nil {
+					t.Fatalf("%s: decoding %s (%s): %v", pos, s, m.String(), err)
+				}
+				if decoded.String() != m.String() || decoded.tags != m.tags {
+					t.Fatalf("%s: expected %s to decode to %s, got %s", pos, s, m.String(), decoded)
+				}
+			}
+		}
+	}
+}
+
+func TestScannerICCFNotation(t *testing.T) {
+	pgn := `[Event "ICCF"]
+[Result "1-0"]
+
+1. 5254 5755 2. 6134 2836 3. 4185 7866 4. 8567 1-0
+`
+	scanner := NewScanner(strings.NewReader(pgn), WithNotation(ICCFNotation{}))
+	game, err := scanner.ParseNext()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if game.Method() != Checkmate {
+		t.Fatalf("expected checkmate, got %s", game.Method())
+	}
+	expected := "1. e4 e5 2. Bc4 Nc6 3. Qh5 Nf6 4. Qxf7# 1-0"
+	if got := strings.TrimSpace(movetext(game)); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,143 @@
+package chess
+
+import (
+	"strings"
+)
+
+// lenientSAN is a move parsed in lenient mode.
+type lenientSAN struct {
+	castle   MoveTag   // KingSideCastle, QueenSideCastle or 0
+	piece    PieceType // Pawn when no piece letter is given
+	fromFile File      // -1 when not given
+	fromRank Rank      // -1 when not given
+	to       Square
+	promo    PieceType
+}
+
+// lenientSuffixes are the annotations ignored at the end of a move. The
+// en passant suffixes are only stripped after a square.
+//
+//nolint:gochecknoglobals // lookup table.
+var lenientSuffixes = []string{"e.p.", "ep", "+", "#", "!", "?"}
+
+// parseLenientSAN parses the variants of SAN accepted in lenient mode.
+func parseLenientSAN(s string) (lenientSAN, bool) {
+	s = strings.TrimSpace(s)
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, suffix := range lenientSuffixes {
+			rest := strings.TrimRight(strings.TrimSuffix(s, suffix), " ")
+			if rest != s && rest != "" && (suffix[0] != 'e' || isDigit(rest[len(rest)-1])) {
+				s, trimmed = rest, true
+			}
+		}
+	}
+
+	switch strings.ToUpper(strings.ReplaceAll(s, "0", "O")) {
+	case castleKS:
+		return lenientSAN{castle: KingSideCastle}, true
+	case castleQS:
+		return lenientSAN{castle: QueenSideCastle}, true
+	}
+
+	m := lenientSAN{piece: Pawn, fromFile: -1, fromRank: -1}
+	if n := len(s); n >= 3 && s[n-3] == '(' && s[n-1] == ')' {
+		if m.promo = lenientPromotion(s[n-2]); m.promo == NoPieceType {
+			return m, false
+		}
+		s = s[:n-3]
+	} else if n >= 3 && isDigit(s[n-2]) || n >= 4 && (s[n-2] == '=' || s[n-2] == '/') {
+		if m.promo = lenientPromotion(s[n-1]); m.promo != NoPieceType {
+			s = strings.TrimRight(s[:n-1], "=/")
+		}
+	}
+
+	n := len(s)
+	if n < 2 || !isFile(s[n-2]) || !isRank(s[n-1]) {
+		return m, false
+	}
+	m.to = NewSquare(File(s[n-2]-'a'), Rank(s[n-1]-'1'))
+	prefix := s[:n-2]
+	if prefix != "" && strings.IndexByte("KQRBNPkqrnp", prefix[0]) >= 0 {
+		m.piece = parsePieceType(strings.ToUpper(prefix[:1]))
+		prefix = prefix[1:]
+	}
+	if prefix != "" && isFile(prefix[0]) {
+		m.fromFile = File(prefix[0] - 'a')
+		prefix = prefix[1:]
+	}
+	if prefix != "" && isRank(prefix[0]) {
+		m.fromRank = Rank(prefix[0] - '1')
+		prefix = prefix[1:]
+	}
+	// a capture sign is accepted on any move, missing or extra
+	switch prefix {
+	case "", "-", "x", "X", ":":
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// lenientPromotion returns the piece type of a promotion letter in either
+// case, or NoPieceType.
+func lenientPromotion(c byte) PieceType {
+	switch c {
+	case 'Q', 'q':
+		return Queen
+	case 'R', 'r':
+		return Rook
+	case 'B', 'b':
+		return Bishop
+	case 'N', 'n':
+		return Knight
+	}
+	return NoPieceType
+}
+
+// match reports whether m is described by l in pos.
+func (l lenientSAN) match(pos *Position, m *Move) bool {
+	if l.castle != 0 {
+		return m.HasTag(l.castle)
+	}
+	if pos.Board().Piece(m.s1).Type() != l.piece || m.s2 != l.to {
+		return false
+	}
+	if l.fromFile >= 0 && m.s1.File() != l.fromFile || l.fromRank >= 0 && m.s1.Rank() != l.fromRank {
+		return false
+	}
+	return m.promo == l.promo
+}
+
+// decodeLenientSAN decodes a move in lenient mode. Moves that can't be
+// matched to a single legal move are reported with a *MoveDecodeError.
+func decodeLenientSAN(pos *Position, s string) (*Move, error) {
+	l, ok := parseLenientSAN(s)
+	if !ok {
+		return nil, &MoveDecodeError{Err: ErrNoSuchMove, Move: s}
+	}
+
+	var found []Move
+	for _, m := range pos.ValidMoves() {
+		if l.match(pos, &m) {
+			found = append(found, m)
+		}
+	}
+	switch len(found) {
+	case 1:
+		return &found[0], nil
+	case 0:
+		for _, m := range pseudoLegalMoves(pos) {
+			if m.HasTag(inCheck) && l.match(pos, &m) {
+				return nil, &MoveDecodeError{Err: ErrIllegalMove, Move: s}
+			}
+		}
+		return nil, &MoveDecodeError{Err: ErrNoSuchMove, Move: s}
+	}
+
+	candidates := make([]string, len(found))
+	for i := range found {
+		candidates[i] = AlgebraicNotation{}.Encode(pos, &found[i])
+	}
+	return nil, &MoveDecodeError{Err: ErrAmbiguousMove, Move: s, Candidates: candidates}
+}
@@ -0,0 +1,81 @@
+package chess
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLenientAlgebraicNotationDecode(t *testing.T) {
+	tests := []struct {
+		fen      string
+		move     string
+		expected string
+	}{
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "0-0", "e1g1"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "o-o-o", "e1c1"},
+		{"r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1", "O-O+", "e1g1"},
+		{"4k3/1P6/8/8/8/8/8/4K3 w - - 0 1", "b8Q", "b7b8q"},
+		{"4k3/1P6/8/8/8/8/8/4K3 w - - 0 1", "b8=N", "b7b8n"},
+		{"4k3/1P6/8/8/8/8/8/4K3 w - - 0 1", "b8(R)", "b7b8r"},
+		{"4k3/1P6/8/8/8/8/8/4K3 w - - 0 1", "b8/b", "b7b8b"},
+		{"4k3/1P6/8/8/8/8/8/4K3 w - - 0 1", "b7b8q", "b7b8q"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "Pe4", "e2e4"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e2-e4", "e2e4"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "Ngf3", "g1f3"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "Ng1f3", "g1f3"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "nf3", "g1f3"},
+		{"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1", "exd6e.p.", "e5d6"},
+		{"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1", "exd6 ep", "e5d6"},
+		{"4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1", "ed6", "e5d6"},
+		{"4k3/8/8/3p4/8/2N5/8/4K3 w - - 0 1", "Nd5", "c3d5"},
+		{"4k3/8/8/3p4/8/2N5/8/4K3 w - - 0 1", "N:d5!?", "c3d5"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "Nxf3", "g1f3"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e2xe4", "e2e4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.move, func(t *testing.T) {
+			m, err := AlgebraicNotation{Lenient: true}.Decode(unsafeFEN(tt.fen), tt.move)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.String() != tt.expected {
+				t.Fatalf("expected %q to decode to %s, got %s", tt.move, tt.expected, m)
+			}
+		})
+	}
+}
+
+func TestAlgebraicNotationDecodeErrors(t *testing.T) {
+	tests := []struct {
+		fen        string
+		move       string
+		lenient    bool
+		err        error
+		candidates []string
+	}{
+		{"4k3/8/8/8/8/8/8/1N2KN2 w - - 0 1", "Nd2", true, ErrAmbiguousMove, []string{"Nbd2", "Nfd2"}},
+		{"4k3/8/8/8/8/8/8/1N2KN2 w - - 0 1", "Nd2", false, ErrAmbiguousMove, []string{"Nbd2", "Nfd2"}},
+		{"4k3/1P6/8/8/8/8/8/4K3 w - - 0 1", "b8", true, ErrNoSuchMove, nil},
+		{"4k3/8/8/8/8/8/8/4K3 w - - 0 1", "Nf3", true, ErrNoSuchMove, nil},
+		{"4k3/8/8/8/8/8/8/4K3 w - - 0 1", "Nf3", false, ErrNoSuchMove, nil},
+		{"4k3/8/8/8/8/8/8/4K3 w - - 0 1", "hello", true, ErrNoSuchMove, nil},
+		{"4r1k1/8/8/8/8/8/4B3/4K3 w - - 0 1", "Bd3", true, ErrIllegalMove, nil},
+		{"4r1k1/8/8/8/8/8/4B3/4K3 w - - 0 1", "Bd3", false, ErrIllegalMove, nil},
+		{"4k3/8/8/8/8/8/8/4K2r w - - 0 1", "Kf1", true, ErrIllegalMove, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.move, func(t *testing.T) {
+			_, err := AlgebraicNotation{Lenient: tt.lenient}.Decode(unsafeFEN(tt.fen), tt.move)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			var derr *MoveDecodeError
+			if !errors.As(err, &derr) || !slices.Equal(derr.Candidates, tt.candidates) {
+				t.Fatalf("expected candidates %v, got %v", tt.candidates, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Lang is a table of the letters designating the pieces in algebraic
+// notation in a language. Letters are usually a single uppercase letter
+// but any non-empty string not starting with a file letter or 'O' works.
+type Lang struct {
+	Name   string
+	King   string
+	Queen  string
+	Rook   string
+	Bishop string
+	Knight string
+}
+
+// Piece letter tables of common languages.
+//
+//nolint:gochecknoglobals // lookup tables.
+var (
+	LangEnglish = Lang{Name: "English", King: "K", Queen: "Q", Rook: "R", Bishop: "B", Knight: "N"}
+	LangGerman  = Lang{Name: "German", King: "K", Queen: "D", Rook: "T", Bishop: "L", Knight: "S"}
+	LangFrench  = Lang{Name: "French", King: "R", Queen: "D", Rook: "T", Bishop: "F", Knight: "C"}
+	LangSpanish = Lang{Name: "Spanish", King: "R", Queen: "D", Rook: "T", Bishop: "A", Knight: "C"}
+	LangDutch   = Lang{Name: "Dutch", King: "K", Queen: "D", Rook: "T", Bishop: "L", Knight: "P"}
+	LangItalian = Lang{Name: "Italian", King: "R", Queen: "D", Rook: "T", Bishop: "A", Knight: "C"}
+)
+
+// letter returns the letter of the piece type, or an empty string for
+// pawns.
+func (l Lang) letter(p PieceType) string {
+	switch p {
+	case King:
+		return l.King
+	case Queen:
+		return l.Queen
+	case Rook:
+		return l.Rook
+	case Bishop:
+		return l.Bishop
+	case Knight:
+		return l.Knight
+	}
+	return ""
+}
+
+// pieceType returns the piece type whose letter starts s along with the
+// length of the letter. The longest letter wins.
+func (l Lang) pieceType(s string) (PieceType, int) {
+	best, n := NoPieceType, 0
+	for _, p := range []PieceType{King, Queen, Rook, Bishop, Knight} {
+		letter := l.letter(p)
+		if letter != "" && len(letter) > n && strings.HasPrefix(s, letter) {
+			best, n = p, len(letter)
+		}
+	}
+	return best, n
+}
+
+// LocalizedAlgebraicNotation is Standard Algebraic Notation with the piece
+// letters of a language, e.g. Sf3 in German or Cf3 in French for Nf3.
+// The zero value uses English letters.
+type LocalizedAlgebraicNotation struct {
+	Lang Lang
+}
+
+// String implements the fmt.Stringer interface and returns
+// the notation's name.
+func (n LocalizedAlgebraicNotation) String() string {
+	return "Algebraic Notation (" + n.lang().Name + ")"
+}
+
+func (n LocalizedAlgebraicNotation) lang() Lang {
+	if n.Lang == (Lang{}) {
+		return LangEnglish
+	}
+	return n.Lang
+}
+
+// Encode implements the Encoder interface.
+func (n LocalizedAlgebraicNotation) Encode(pos *Position, m *Move) string {
+	return translateSAN(AlgebraicNotation{}.Encode(pos, m), LangEnglish, n.lang())
+}
+
+// Decode implements the Decoder interface. Castling may be written with
+// the letter O or the digit 0.
+func (n LocalizedAlgebraicNotation) Decode(pos *Position, s string) (*Move, error) {
+	english, err := localizedToSAN(s, n.lang())
+	if err != nil {
+		return nil, err
+	}
+	return AlgebraicNotation{}.Decode(pos, english)
+}
+
+// translateSAN replaces the piece letters of a SAN move, found at the start
+// and after the promotion sign, from one table to another.
+func translateSAN(s string, from, to Lang) string {
+	if from == to || strings.HasPrefix(s, "O-O") {
+		return s
+	}
+	if p, n := from.pieceType(s); p != NoPieceType {
+		s = to.letter(p) + s[n:]
+	}
+	if i := strings.IndexByte(s, '='); i >= 0 {
+		if p, n := from.pieceType(s[i+1:]); p != NoPieceType {
+			s = s[:i+1] + to.letter(p) + s[i+1+n:]
+		}
+	}
+	return s
+}
+
+// localizedToSAN converts a move written with the letters of lang to SAN.
+func localizedToSAN(s string, lang Lang) (string, error) {
+	if strings.HasPrefix(s, "0-0") {
+		return strings.ReplaceAll(s, "0", "O"), nil
+	}
+	if s == "" || strings.HasPrefix(s, "O-O") || s[0] >= 'a' && s[0] <= 'h' {
+		return translateSAN(s, lang, LangEnglish), nil
+	}
+	if p, _ := lang.pieceType(s); p == NoPieceType {
+		r, _ := utf8.DecodeRuneInString(s)
+		return "", fmt.Errorf("chess: invalid %s piece letter %q in %q", lang.Name, r, s)
+	}
+	return translateSAN(s, lang, LangEnglish), nil
+}
@@ -0,0 +1,66 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestLocalizedAlgebraicNotation(t *testing.T) {
+	// White can castle, promote with capture and play a knight or bishop move
+	pos := unsafeFEN("rn2k3/1P6/8/8/8/8/8/R3KB1N w Q - 0 1")
+	tests := []struct {
+		lang     Lang
+		uci      string
+		expected string
+	}{
+		{LangGerman, "h1g3", "Sg3"},
+		{LangGerman, "f1d3", "Ld3"},
+		{LangGerman, "b7a8q", "bxa8=D"},
+		{LangGerman, "e1c1", "O-O-O"},
+		{LangFrench, "h1g3", "Cg3"},
+		{LangFrench, "e1d2", "Rd2"},
+		{LangFrench, "a1a7", "Ta7"},
+		{LangSpanish, "f1d3", "Ad3"},
+		{LangSpanish, "b7a8n", "bxa8=C"},
+		{LangDutch, "h1g3", "Pg3"},
+		{LangEnglish, "h1g3", "Ng3"},
+		{Lang{}, "f1d3", "Bd3"},
+		{Lang{Name: "Custom", King: "Kg", Queen: "Qn", Rook: "Rk", Bishop: "Bp", Knight: "Kn"}, "h1g3", "Kng3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang.Name+" "+tt.expected, func(t *testing.T) {
+			n := LocalizedAlgebraicNotation{Lang: tt.lang}
+			m, err := UCINotation{}.Decode(pos, tt.uci)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := n.Encode(pos, m); got != tt.expected {
+				t.Fatalf("expected %s, got %s", tt.expected, got)
+			}
+			decoded, err := n.Decode(pos, tt.expected)
+			if err != nil {
+				t.Fatalf("decoding %s: %v", tt.expected, err)
+			}
+			if decoded.String() != m.String() {
+				t.Fatalf("expected %s to decode to %s, got %s", tt.expected, m, decoded)
+			}
+		})
+	}
+}
+
+func TestLocalizedAlgebraicNotationDecode(t *testing.T) {
+	pos := unsafeFEN("r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1")
+	n := LocalizedAlgebraicNotation{Lang: LangGerman}
+	m, err := n.Decode(pos, "0-0")
+	if err != nil || !m.HasTag(KingSideCastle) {
+		t.Fatalf("expected 0-0 to decode to a king side castle, got %v %v", m, err)
+	}
+	for _, s := range []string{"Ra2", "Xa2", "Na2", ""} {
+		if _, err := n.Decode(pos, s); err == nil {
+			t.Errorf("expected %q to be invalid in German", s)
+		}
+	}
+	if s := n.String(); s != "Algebraic Notation (German)" {
+		t.Errorf("unexpected name %q", s)
+	}
+}
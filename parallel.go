@@ -0,0 +1,247 @@
+package chess
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// ParsedGame is a game parsed by Scanner.ParseParallel.
+type ParsedGame struct {
+	Game  *Game // Parsed game, nil if Err is set
+	Err   error // Error returned by the parser for this game
+	Index int   // Index of the game in the Scanner, starting at 0
+}
+
+// ParallelOption configures Scanner.ParseParallel.
+type ParallelOption func(*parallelConfig)
+
+type parallelConfig struct {
+	workers     int
+	maxInFlight int
+	unordered   bool
+}
+
+// WithWorkers sets the number of goroutines parsing games.
+// It defaults to runtime.GOMAXPROCS(0).
+func WithWorkers(n int) ParallelOption {
+	return func(c *parallelConfig) {
+		c.workers = n
+	}
+}
+
+// WithMaxInFlight bounds the number of games read from the source but not
+// yet delivered, which bounds memory usage. It defaults to four times the
+// number of workers.
+func WithMaxInFlight(n int) ParallelOption {
+	return func(c *parallelConfig) {
+		c.maxInFlight = n
+	}
+}
+
+// WithUnordered delivers games as soon as they are parsed instead of in
+// their order in the source, which avoids holding back parsed games
+// behind a slow one.
+func WithUnordered() ParallelOption {
+	return func(c *parallelConfig) {
+		c.unordered = true
+	}
+}
+
+// parallelResult is the outcome of parsing the scanned game with sequence
+// number seq.
+type parallelResult struct {
+	err   error
+	games []*Game
+	seq   int
+	index int
+}
+
+// ParseParallel parses the remaining games of the scanner with a pool of
+// workers and calls fn with each of them. Games are split from the source
+// sequentially, then tokenized and parsed concurrently with the options of
+// the scanner. By default, fn is called in the order of the games in the
+// source; fn is never called concurrently.
+//
+// A game that fails to parse is delivered with its error and doesn't stop
+// the other games. ParseParallel returns when all games have been
+// delivered, when fn returns an error, which is returned, or when ctx is
+// done, in which case ctx.Err() is returned. Once fn returned an error or
+// ctx is done, fn is not called again. Errors reading the source are
+// returned as well. All goroutines have exited when ParseParallel returns.
+//
+// Example:
+//
+//	err := scanner.ParseParallel(ctx, func(pg chess.ParsedGame) error {
+//	    if pg.Err != nil {
+//	        log.Printf("game %d: %v", pg.Index, pg.Err)
+//	        return nil
+//	    }
+//	    return store(pg.Game)
+//	})
+func (s *Scanner) ParseParallel(ctx context.Context, fn func(ParsedGame) error, opts ...ParallelOption) error {
+	cfg := parallelConfig{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.workers < 1 {
+		cfg.workers = 1
+	}
+	if cfg.maxInFlight < 1 {
+		cfg.maxInFlight = 4 * cfg.workers
+	}
+
+	// games already expanded by ParseNext
+	for len(s.nextParsedGames) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		game := s.nextParsedGames[0]
+		s.nextParsedGames = s.nextParsedGames[1:]
+		if err := fn(ParsedGame{Game: game, Index: s.count - 1}); err != nil {
+			return err
+		}
+	}
+
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		game *GameScanned
+		seq  int
+	}
+	jobs := make(chan job)
+	// every job holds a slot until it is delivered, so that results
+	// never block workers
+	slots := make(chan struct{}, cfg.maxInFlight)
+	results := make(chan parallelResult, cfg.maxInFlight)
+
+	var scanErr error
+	go func() {
+		defer close(jobs)
+		for seq := 0; ; seq++ {
+			select {
+			case slots <- struct{}{}:
+			case <-workCtx.Done():
+				return
+			}
+			game, err := s.ScanGame()
+			if err != nil {
+				if err != io.EOF {
+					scanErr = err
+				}
+				return
+			}
+			select {
+			case jobs <- job{game: game, seq: seq}:
+			case <-workCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range cfg.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				r := parallelResult{seq: j.seq, index: j.game.Index}
+				game, err := s.parseScanned(j.game)
+				switch {
+				case err != nil:
+					r.err = err
+				case s.opts.ExpandVariations:
+					r.games = game.Split()
+				default:
+					r.games = []*Game{game}
+				}
+				results <- r
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var fnErr error
+	deliver := func(r parallelResult) {
+		<-slots
+		// fn is not called anymore once it failed or ctx is done, even
+		// for games parsed in the meantime
+		if fnErr != nil || workCtx.Err() != nil {
+			return
+		}
+		if r.err != nil {
+			fnErr = fn(ParsedGame{Err: r.err, Index: r.index})
+		}
+		for _, game := range r.games {
+			if fnErr != nil || workCtx.Err() != nil {
+				break
+			}
+			fnErr = fn(ParsedGame{Game: game, Index: r.index})
+		}
+		if fnErr != nil {
+			cancel()
+		}
+	}
+
+	pending := make(map[int]parallelResult)
+	next := 0
+	// results is closed once the producer and the workers have exited
+	for r := range results {
+		if cfg.unordered {
+			deliver(r)
+			continue
+		}
+		pending[r.seq] = r
+		for {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			deliver(r)
+		}
+	}
+
+	if fnErr != nil {
+		return fnErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if scanErr != nil {
+		s.lastError = scanErr
+	}
+	return scanErr
+}
+
+// ParseParallelChan is like ParseParallel but delivers the games on the
+// returned channel, which is closed once all games have been delivered or
+// ctx is done. An error reading the source is delivered last, with an
+// Index of -1. Callers must either drain the channel or cancel ctx.
+func (s *Scanner) ParseParallelChan(ctx context.Context, opts ...ParallelOption) <-chan ParsedGame {
+	out := make(chan ParsedGame)
+	go func() {
+		defer close(out)
+		err := s.ParseParallel(ctx, func(pg ParsedGame) error {
+			select {
+			case out <- pg:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, opts...)
+		if err != nil && ctx.Err() == nil {
+			select {
+			case out <- ParsedGame{Err: err, Index: -1}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,225 @@
+package chess
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// parallelFixture returns a PGN with many games and a bad one at index 6.
+func parallelFixture() string {
+	game := mustParsePGN("fixtures/pgns/multi_game.pgn")
+	games := make([]string, 0, 11)
+	for range 10 {
+		games = append(games, strings.TrimSpace(game))
+	}
+	bad := "[Event \"Bad\"]\n\n1. e4 e5 2. Ke3 1-0"
+	games = slices.Insert(games, 1, bad)
+	return strings.Join(games, "\n\n") + "\n"
+}
+
+// parseSerial parses pgn with ParseNext, recording errors as nil games.
+func parseSerial(t *testing.T, pgn string) []*Game {
+	t.Helper()
+	var games []*Game
+	scanner := NewScanner(strings.NewReader(pgn))
+	for scanner.HasNext() {
+		game, err := scanner.ParseNext()
+		if err != nil {
+			game = nil
+		}
+		games = append(games, game)
+	}
+	return games
+}
+
+func TestParseParallelOrdered(t *testing.T) {
+	pgn := parallelFixture()
+	expected := parseSerial(t, pgn)
+
+	var got []ParsedGame
+	scanner := NewScanner(strings.NewReader(pgn))
+	err := scanner.ParseParallel(context.Background(), func(pg ParsedGame) error {
+		got = append(got, pg)
+		return nil
+	}, WithWorkers(4), WithMaxInFlight(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d games, got %d", len(expected), len(got))
+	}
+	for i, pg := range got {
+		if pg.Index != i {
+			t.Errorf("expected game %d, got index %d", i, pg.Index)
+		}
+		if expected[i] == nil {
+			var perr *ParserError
+			if !errors.As(pg.Err, &perr) || perr.GameIndex != i {
+				t.Errorf("expected a parse error for game %d, got %v", i, pg.Err)
+			}
+			continue
+		}
+		if pg.Err != nil {
+			t.Fatalf("unexpected error for game %d: %v", i, pg.Err)
+		}
+		if pg.Game.String() != expected[i].String() {
+			t.Errorf("game %d differs:\n%s\n%s", i, pg.Game, expected[i])
+		}
+	}
+}
+
+func TestParseParallelUnordered(t *testing.T) {
+	pgn := parallelFixture()
+	expected := parseSerial(t, pgn)
+
+	var indexes []int
+	scanner := NewScanner(strings.NewReader(pgn))
+	err := scanner.ParseParallel(context.Background(), func(pg ParsedGame) error {
+		indexes = append(indexes, pg.Index)
+		return nil
+	}, WithWorkers(4), WithUnordered())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slices.Sort(indexes)
+	for i, index := range indexes {
+		if index != i {
+			t.Fatalf("expected every game once, got indexes %v", indexes)
+		}
+	}
+	if len(indexes) != len(expected) {
+		t.Fatalf("expected %d games, got %d", len(expected), len(indexes))
+	}
+}
+
+func TestParseParallelStop(t *testing.T) {
+	pgn := parallelFixture()
+	errStop := errors.New("stop")
+
+	count := 0
+	scanner := NewScanner(strings.NewReader(pgn))
+	err := scanner.ParseParallel(context.Background(), func(ParsedGame) error {
+		count++
+		if count == 3 {
+			return errStop
+		}
+		return nil
+	}, WithWorkers(2))
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected the callback error, got %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("expected the callback to be called 3 times, got %d", count)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	count = 0
+	scanner = NewScanner(strings.NewReader(pgn))
+	err = scanner.ParseParallel(ctx, func(ParsedGame) error {
+		count++
+		cancel()
+		return nil
+	}, WithWorkers(2))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if count >= len(parseSerial(t, pgn)) {
+		t.Fatalf("expected cancellation to stop parsing, got %d games", count)
+	}
+}
+
+func TestParseParallelCancelInCallback(t *testing.T) {
+	pgn := parallelFixture()
+	for name, opts := range map[string][]ParallelOption{
+		"ordered":   {WithWorkers(4), WithMaxInFlight(16)},
+		"unordered": {WithWorkers(4), WithMaxInFlight(16), WithUnordered()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			count := 0
+			scanner := NewScanner(strings.NewReader(pgn), WithExpandVariations())
+			err := scanner.ParseParallel(ctx, func(ParsedGame) error {
+				count++
+				cancel()
+				return nil
+			}, opts...)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got %v", err)
+			}
+			if count != 1 {
+				t.Fatalf("expected the callback not to be called after cancellation, got %d calls", count)
+			}
+		})
+	}
+}
+
+func TestParseParallelChan(t *testing.T) {
+	pgn := parallelFixture()
+	expected := parseSerial(t, pgn)
+
+	scanner := NewScanner(strings.NewReader(pgn))
+	i := 0
+	for pg := range scanner.ParseParallelChan(context.Background()) {
+		if pg.Index != i {
+			t.Fatalf("expected game %d, got %d", i, pg.Index)
+		}
+		i++
+	}
+	if i != len(expected) {
+		t.Fatalf("expected %d games, got %d", len(expected), i)
+	}
+}
+
+func loadBigPGN(b *testing.B) []byte {
+	b.Helper()
+	data, err := os.ReadFile("fixtures/pgns/big.pgn")
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+func BenchmarkParseNextBig(b *testing.B) {
+	data := loadBigPGN(b)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for range b.N {
+		scanner := NewScanner(bytes.NewReader(data))
+		for scanner.HasNext() {
+			if _, err := scanner.ParseNext(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func benchmarkParseParallelBig(b *testing.B, opts ...ParallelOption) {
+	data := loadBigPGN(b)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for range b.N {
+		scanner := NewScanner(bytes.NewReader(data))
+		err := scanner.ParseParallel(context.Background(), func(pg ParsedGame) error {
+			return pg.Err
+		}, opts...)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseParallelBig(b *testing.B) {
+	benchmarkParseParallelBig(b)
+}
+
+func BenchmarkParseParallelBigUnordered(b *testing.B) {
+	benchmarkParseParallelBig(b, WithUnordered())
+}
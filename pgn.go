@@ -17,6 +17,8 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/exp/maps"
 )
@@ -26,8 +28,37 @@ type Parser struct {
 	game        *Game
 	currentMove *Move
 	tokens      []Token
-	errors      []ParserError
+	errors      []ParserError // warnings recorded in lenient mode
 	position    int
+	fenToken    int  // index of the FEN tag value token
+	inMoveText  bool // whether the header has been parsed
+	foundResult bool // whether the game termination marker was parsed
+	lenient     bool
+	decoder     Decoder // decodes RawMove tokens
+}
+
+// ParserOption configures a Parser.
+type ParserOption func(*Parser)
+
+// WithLenient makes the parser recover from problems commonly found in
+// real-world PGN instead of failing: invalid tokens and malformed tag pairs
+// are skipped, castling written with zeros and promotions without '=' are
+// repaired, invalid UTF-8 in tags and comments is replaced, and a missing
+// result is accepted. When a move can't be matched to a legal move, the
+// rest of its line is dropped. Each recovered problem is recorded as a
+// warning available through Game.Warnings.
+func WithLenient() ParserOption {
+	return func(p *Parser) {
+		p.lenient = true
+	}
+}
+
+// WithDecoder sets the notation used to decode RawMove tokens, produced by
+// a lexer created with WithRawMoves. The default is AlgebraicNotation.
+func WithDecoder(d Decoder) ParserOption {
+	return func(p *Parser) {
+		p.decoder = d
+	}
 }
 
 // NewParser creates a new parser instance initialized with the given tokens.
@@ -37,11 +68,11 @@ type Parser struct {
 //
 //	tokens := TokenizeGame(game)
 //	parser := NewParser(tokens)
-func NewParser(tokens []Token) *Parser {
+func NewParser(tokens []Token, opts ...ParserOption) *Parser {
 	rootMove := &Move{
 		position: StartingPosition(),
 	}
-	return &Parser{
+	p := &Parser{
 		tokens: tokens,
 		game: &Game{
 			tagPairs:    make(TagPairs),
@@ -51,6 +82,10 @@ func NewParser(tokens []Token) *Parser {
 		},
 		currentMove: rootMove,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // currentToken returns the current token being processed.
@@ -66,6 +101,119 @@ func (p *Parser) advance() {
 	p.position++
 }
 
+// newError returns a ParserError located at the current token.
+func (p *Parser) newError(msg string) *ParserError {
+	return p.errorAt(p.position, msg)
+}
+
+// errorAt returns a ParserError located at the token with the given index.
+// Past the last token, the error is located at the end of the last token.
+func (p *Parser) errorAt(idx int, msg string) *ParserError {
+	err := &ParserError{
+		Message:   msg,
+		Position:  idx,
+		GameIndex: -1,
+	}
+	switch {
+	case idx < len(p.tokens):
+		tok := p.tokens[idx]
+		err.TokenType = tok.Type
+		err.TokenValue = tok.Value
+		err.Offset = tok.Offset
+		err.Line = tok.Line
+		err.Column = tok.Column
+	case len(p.tokens) > 0:
+		tok := p.tokens[len(p.tokens)-1]
+		err.TokenType = EOF
+		err.Offset = tok.Offset + len(tok.Value)
+		err.Line = tok.Line
+		err.Column = tok.Column + utf8.RuneCountInString(tok.Value)
+	}
+	if p.inMoveText && p.game.pos != nil {
+		err.MoveNumber = p.game.pos.moveCount
+	}
+	return err
+}
+
+// tolerate records err as a warning and reports whether parsing can go on,
+// which is only the case in lenient mode.
+func (p *Parser) tolerate(err error) bool {
+	var perr *ParserError
+	if !p.lenient || !errors.As(err, &perr) {
+		return false
+	}
+	p.errors = append(p.errors, *perr)
+	return true
+}
+
+// warn records a warning located at the token with the given index.
+func (p *Parser) warn(idx int, msg string) {
+	p.errors = append(p.errors, *p.errorAt(idx, msg))
+}
+
+// skipLine skips the remaining tokens of the current line, up to the
+// end of the enclosing variation or the game result.
+func (p *Parser) skipLine() {
+	depth := 0
+	for ; p.position < len(p.tokens); p.advance() {
+		switch p.currentToken().Type {
+		case VariationStart:
+			depth++
+		case VariationEnd:
+			if depth == 0 {
+				return
+			}
+			depth--
+		case RESULT:
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// repairToken fixes the current token in lenient mode, reporting
+// whether it should be skipped instead.
+func (p *Parser) repairToken() bool {
+	if !p.lenient {
+		return false
+	}
+	tok := &p.tokens[p.position]
+	switch {
+	case tok.Error != nil:
+		// a run of invalid tokens is reported once
+		if p.position == 0 || p.tokens[p.position-1].Error == nil {
+			p.warn(p.position, "skipped invalid token: "+tok.Error.Error())
+		}
+		return true
+	case tok.Type == MoveNumber && tok.Value == "0-0":
+		tok.Type = KingsideCastle
+		p.warn(p.position, "castling written with zeros")
+	case tok.Type == MoveNumber && tok.Value == "0-0-0":
+		tok.Type = QueensideCastle
+		p.warn(p.position, "castling written with zeros")
+	}
+	return false
+}
+
+// validText returns s with invalid UTF-8 replaced in lenient mode.
+func (p *Parser) validText(idx int, s string) string {
+	if !p.lenient || utf8.ValidString(s) {
+		return s
+	}
+	p.warn(idx, "invalid UTF-8 replaced")
+	return strings.ToValidUTF8(s, "\uFFFD")
+}
+
+// tokenText returns the concatenated values of the tokens in [from, to).
+func (p *Parser) tokenText(from, to int) string {
+	var sb strings.Builder
+	for i := from; i < to && i < len(p.tokens); i++ {
+		sb.WriteString(p.tokens[i].Value)
+	}
+	return sb.String()
+}
+
 // Parse processes all tokens and returns the complete game.
 // This includes parsing header information (tags), moves,
 // variations, comments, and the game result.
@@ -82,28 +230,35 @@ func (p *Parser) advance() {
 func (p *Parser) Parse() (*Game, error) {
 	// Parse header section (tag pairs)
 	if err := p.parseHeader(); err != nil {
-		return nil, errors.New("parsing header")
+		return nil, err
 	}
 
 	// check if the game has a starting position
 	if value, ok := p.game.tagPairs["FEN"]; ok {
 		pos, err := decodeFEN(value)
 		if err != nil {
-			return nil, errors.New("invalid FEN")
+			perr := p.errorAt(p.fenToken, "invalid FEN: "+err.Error())
+			perr.Err = err
+			return nil, perr
 		}
 		p.game.rootMove.position = pos
 		p.game.pos = pos
 	}
 
 	// Parse moves section
+	p.inMoveText = true
 	if err := p.parseMoveText(); err != nil {
 		return nil, err
 	}
 
+	if !p.foundResult && p.lenient {
+		p.warn(len(p.tokens), "missing game result")
+	}
 	if p.game.outcome == UnknownOutcome {
 		p.game.outcome = NoOutcome
 	}
 	p.game.currentMove = p.currentMove
+	p.game.warnings = p.errors
 
 	return p.game, nil
 }
@@ -111,56 +266,57 @@ func (p *Parser) Parse() (*Game, error) {
 func (p *Parser) parseHeader() error {
 	for p.currentToken().Type == TagStart {
 		if err := p.parseTagPair(); err != nil {
-			return err
+			if !p.tolerate(err) {
+				return err
+			}
+			p.skipTagPair()
 		}
 	}
 	return nil
 }
 
+// skipTagPair skips the rest of a malformed tag pair.
+func (p *Parser) skipTagPair() {
+	for {
+		switch p.currentToken().Type {
+		case TagKey, TagValue, Undefined:
+			p.advance()
+		case TagEnd:
+			p.advance()
+			return
+		default:
+			return
+		}
+	}
+}
+
 func (p *Parser) parseTagPair() error {
 	// Expect [
 	if p.currentToken().Type != TagStart {
-		return &ParserError{
-			Message:    "expected tag start",
-			TokenType:  p.currentToken().Type,
-			TokenValue: p.currentToken().Value,
-			Position:   p.position,
-		}
+		return p.newError("expected tag start")
 	}
 	p.advance()
 
 	// Get key
 	if p.currentToken().Type != TagKey {
-		return &ParserError{
-			Message:    "expected tag key",
-			TokenType:  p.currentToken().Type,
-			TokenValue: p.currentToken().Value,
-			Position:   p.position,
-		}
+		return p.newError("expected tag key")
 	}
 	key := p.currentToken().Value
 	p.advance()
 
 	// Get value
 	if p.currentToken().Type != TagValue {
-		return &ParserError{
-			Message:    "expected tag value",
-			TokenType:  p.currentToken().Type,
-			TokenValue: p.currentToken().Value,
-			Position:   p.position,
-		}
+		return p.newError("expected tag value")
+	}
+	value := p.validText(p.position, p.currentToken().Value)
+	if key == "FEN" {
+		p.fenToken = p.position
 	}
-	value := p.currentToken().Value
 	p.advance()
 
 	// Expect ]
 	if p.currentToken().Type != TagEnd {
-		return &ParserError{
-			Message:    "expected tag end",
-			TokenType:  p.currentToken().Type,
-			TokenValue: p.currentToken().Value,
-			Position:   p.position,
-		}
+		return p.newError("expected tag end")
 	}
 	p.advance()
 
@@ -173,6 +329,10 @@ func (p *Parser) parseMoveText() error {
 	var moveNumber uint64
 	ply := 1
 	for p.position < len(p.tokens) {
+		if p.repairToken() {
+			p.advance()
+			continue
+		}
 		token := p.currentToken()
 
 		switch token.Type {
@@ -191,10 +351,14 @@ func (p *Parser) parseMoveText() error {
 			p.advance()
 			ply++
 
-		case PIECE, SQUARE, FILE, KingsideCastle, QueensideCastle:
+		case PIECE, SQUARE, FILE, KingsideCastle, QueensideCastle, RawMove:
 			move, err := p.parseMove()
 			if err != nil {
-				return err
+				if !p.tolerate(err) {
+					return err
+				}
+				p.skipLine()
+				continue
 			}
 			if moveNumber > 0 {
 				move.number = uint(moveNumber)
@@ -208,11 +372,11 @@ func (p *Parser) parseMoveText() error {
 				tok := p.currentToken()
 				switch tok.Type {
 				case NAG:
-					p.currentMove.nag = tok.Value
+					p.currentMove.nags = append(p.currentMove.nags, tok.Value)
 					p.advance()
 				case CommentStart:
 					comment, commandMap, err := p.parseComment()
-					if err != nil {
+					if err != nil && !p.tolerate(err) {
 						return err
 					}
 					if p.currentMove != nil {
@@ -234,7 +398,7 @@ func (p *Parser) parseMoveText() error {
 
 		case CommentStart:
 			comment, commandMap, err := p.parseComment()
-			if err != nil {
+			if err != nil && !p.tolerate(err) {
 				return err
 			}
 			if p.currentMove != nil {
@@ -269,6 +433,11 @@ func (p *Parser) parseMoveText() error {
 // parseMove processes tokens until it has a complete move, then validates against legal moves.
 func (p *Parser) parseMove() (*Move, error) {
 	move := &Move{}
+	start := p.position
+
+	if p.currentToken().Type == RawMove {
+		return p.parseRawMove()
+	}
 
 	// Handle castling first as it's a special case
 	if p.currentToken().Type == KingsideCastle {
@@ -285,12 +454,7 @@ func (p *Parser) parseMove() (*Move, error) {
 				return move, nil
 			}
 		}
-		return nil, &ParserError{
-			Message:    "illegal kingside castle",
-			TokenType:  p.currentToken().Type,
-			TokenValue: p.currentToken().Value,
-			Position:   p.position,
-		}
+		return nil, p.newError("illegal kingside castle")
 	}
 
 	if p.currentToken().Type == QueensideCastle {
@@ -307,12 +471,7 @@ func (p *Parser) parseMove() (*Move, error) {
 				return move, nil
 			}
 		}
-		return nil, &ParserError{
-			Message:    "illegal queenside castle",
-			TokenType:  p.currentToken().Type,
-			TokenValue: p.currentToken().Value,
-			Position:   p.position,
-		}
+		return nil, p.newError("illegal queenside castle")
 	}
 
 	// Parse regular move
@@ -362,13 +521,9 @@ func (p *Parser) parseMove() (*Move, error) {
 
 	// Get destination square
 	if p.currentToken().Type != SQUARE {
-		return nil, &ParserError{
-			Message:    "expected destination square",
-			TokenType:  p.currentToken().Type,
-			TokenValue: p.currentToken().Value,
-			Position:   p.position,
-		}
+		return nil, p.newError("expected destination square")
 	}
+	destToken := p.position
 	moveData.destSquare = p.currentToken().Value
 	p.advance()
 
@@ -376,31 +531,27 @@ func (p *Parser) parseMove() (*Move, error) {
 	if p.currentToken().Type == PROMOTION {
 		p.advance()
 		if p.currentToken().Type != PromotionPiece {
-			return nil, &ParserError{
-				Message:    "expected promotion piece",
-				TokenType:  p.currentToken().Type,
-				TokenValue: p.currentToken().Value,
-				Position:   p.position,
-			}
+			return nil, p.newError("expected promotion piece")
 		}
 		moveData.promotion = parsePieceType(p.currentToken().Value)
 		p.advance()
+	} else if p.lenient && moveData.piece == "" && p.currentToken().Type == PIECE &&
+		len(moveData.destSquare) == 2 && (moveData.destSquare[1] == '1' || moveData.destSquare[1] == '8') {
+		// promotion without '=', e.g. e8Q
+		p.warn(p.position, "promotion without '='")
+		moveData.promotion = parsePieceType(p.currentToken().Value)
+		p.advance()
 	}
 
 	// Get target square
 	targetSquare := parseSquare(moveData.destSquare)
 	if targetSquare == NoSquare {
-		return nil, &ParserError{
-			Message:    "invalid destination square",
-			TokenType:  p.currentToken().Type,
-			TokenValue: p.currentToken().Value,
-			Position:   p.position,
-		}
+		return nil, p.errorAt(destToken, "invalid destination square")
 	}
 
 	// Find matching legal move
 	var matchingMove *Move
-	var err error
+	var mismatch string
 	validMoves := p.game.pos.ValidMoves()
 	for _, m := range validMoves {
 		//nolint:nestif // readability
@@ -410,54 +561,29 @@ func (p *Parser) parseMove() (*Move, error) {
 
 			// Check piece type
 			if moveData.piece != "" && piece.Type() != PieceTypeFromString(moveData.piece) || moveData.piece == "" && piece.Type() != Pawn {
-				err = &ParserError{
-					Message:    "piece type mismatch",
-					TokenType:  p.currentToken().Type,
-					TokenValue: p.currentToken().Value,
-					Position:   p.position,
-				}
+				mismatch = "piece type mismatch"
 				continue
 			}
 
 			// Check disambiguation
 			if moveData.originFile != "" && m.S1().File().String() != moveData.originFile {
-				err = &ParserError{
-					Message:    "origin file mismatch",
-					TokenType:  p.currentToken().Type,
-					TokenValue: p.currentToken().Value,
-					Position:   p.position,
-				}
+				mismatch = "origin file mismatch"
 				continue
 			}
 			if moveData.originRank != "" && strconv.Itoa(int((m.S1()/8)+1)) != moveData.originRank {
-				err = &ParserError{
-					Message:    fmt.Sprintf("origin rank mismatch: %d", m.S1()/8+1),
-					TokenType:  p.currentToken().Type,
-					TokenValue: p.currentToken().Value,
-					Position:   p.position,
-				}
+				mismatch = fmt.Sprintf("origin rank mismatch: %d", m.S1()/8+1)
 				continue
 			}
 
 			// Check capture
 			if moveData.isCapture != (m.HasTag(Capture) || m.HasTag(EnPassant)) {
-				err = &ParserError{
-					Message:    "capture mismatch",
-					TokenType:  p.currentToken().Type,
-					TokenValue: p.currentToken().Value,
-					Position:   p.position,
-				}
+				mismatch = "capture mismatch"
 				continue
 			}
 
 			// Check promotion
 			if moveData.promotion != NoPieceType && m.promo != moveData.promotion {
-				err = &ParserError{
-					Message:    "promotion mismatch",
-					TokenType:  p.currentToken().Type,
-					TokenValue: p.currentToken().Value,
-					Position:   p.position,
-				}
+				mismatch = "promotion mismatch"
 				continue
 			}
 
@@ -467,16 +593,13 @@ func (p *Parser) parseMove() (*Move, error) {
 	}
 
 	if matchingMove == nil {
-		if err != nil {
-			return nil, &ParserError{
-				Message:  fmt.Sprintf("no legal move found for position: %s", err.Error()),
-				Position: p.position,
-			}
-		}
-		return nil, &ParserError{
-			Message:  "no legal move found for position",
-			Position: p.position,
+		msg := "no legal move found for position"
+		if mismatch != "" {
+			msg += ": " + mismatch
 		}
+		err := p.errorAt(start, msg)
+		err.TokenValue = p.tokenText(start, p.position)
+		return nil, err
 	}
 
 	// Copy the matched move details
@@ -486,15 +609,42 @@ func (p *Parser) parseMove() (*Move, error) {
 	move.promo = matchingMove.promo
 	move.position = p.game.pos.copy() // Cache current position
 
+	return p.parseMoveSuffix(move), nil
+}
+
+// parseRawMove decodes a RawMove token with the decoder of the parser.
+func (p *Parser) parseRawMove() (*Move, error) {
+	decoder := p.decoder
+	if decoder == nil {
+		decoder = AlgebraicNotation{}
+	}
+	decoded, err := decoder.Decode(p.game.pos, p.currentToken().Value)
+	if err != nil {
+		perr := p.newError("invalid move: " + err.Error())
+		perr.Err = err
+		return nil, perr
+	}
+	move := legalMove(p.game.pos, decoded.s1, decoded.s2, decoded.promo)
+	if move == nil {
+		return nil, p.newError("no legal move found for position")
+	}
+	move.position = p.game.pos.copy()
+	p.advance()
+	return p.parseMoveSuffix(move), nil
+}
+
+// parseMoveSuffix reads the check marker and NAGs following a move and sets
+// the move number of a black move.
+func (p *Parser) parseMoveSuffix(move *Move) *Move {
 	// Handle check/checkmate if present
 	if p.currentToken().Type == CHECK {
 		move.tags |= Check
 		p.advance()
 	}
 
-	// Handle NAG if present
-	if p.currentToken().Type == NAG {
-		move.nag = p.currentToken().Value
+	// Handle NAGs if present
+	for p.currentToken().Type == NAG {
+		move.nags = append(move.nags, p.currentToken().Value)
 		p.advance()
 	}
 
@@ -505,7 +655,7 @@ func (p *Parser) parseMove() (*Move, error) {
 		}
 	}
 
-	return move, nil
+	return move
 }
 
 func (p *Parser) parseComment() (string, map[string]string, error) {
@@ -531,23 +681,15 @@ func (p *Parser) parseComment() (string, map[string]string, error) {
 			}
 
 		case COMMENT:
-			comment += p.currentToken().Value // Append plain comment text
+			comment += p.validText(p.position, p.currentToken().Value) // Append plain comment text
 		default:
-			return "", nil, &ParserError{
-				Message:    "unexpected token in comment",
-				Position:   p.position,
-				TokenType:  p.currentToken().Type,
-				TokenValue: p.currentToken().Value,
-			}
+			return comment, commandMap, p.newError("unexpected token in comment")
 		}
 		p.advance()
 	}
 
 	if p.position >= len(p.tokens) {
-		return "", nil, &ParserError{
-			Message:  "unterminated comment",
-			Position: p.position,
-		}
+		return comment, commandMap, p.newError("unterminated comment")
 	}
 
 	p.advance() // Consume "}"
@@ -574,21 +716,13 @@ func (p *Parser) parseCommand() (map[string]string, error) {
 				key = "" // Reset key after assigning value
 			}
 		default:
-			return nil, &ParserError{
-				Message:    "unexpected token in command",
-				Position:   p.position,
-				TokenType:  p.currentToken().Type,
-				TokenValue: p.currentToken().Value,
-			}
+			return nil, p.newError("unexpected token in command")
 		}
 		p.advance()
 	}
 
 	if p.position >= len(p.tokens) {
-		return nil, &ParserError{
-			Message:  "unterminated command",
-			Position: p.position,
-		}
+		return nil, p.newError("unterminated command")
 	}
 
 	// p.advance() // Consume the closing "]"
@@ -627,6 +761,10 @@ func (p *Parser) parseVariation(parentMoveNumber uint64, parentPly int) error {
 	isBlackMove := false
 
 	for p.currentToken().Type != VariationEnd && p.position < len(p.tokens) {
+		if p.repairToken() {
+			p.advance()
+			continue
+		}
 		switch p.currentToken().Type {
 		case MoveNumber:
 			num, err := strconv.ParseUint(p.currentToken().Value, 10, 32)
@@ -650,17 +788,22 @@ func (p *Parser) parseVariation(parentMoveNumber uint64, parentPly int) error {
 				return err
 			}
 
-		case PIECE, SQUARE, FILE, KingsideCastle, QueensideCastle:
+		case PIECE, SQUARE, FILE, KingsideCastle, QueensideCastle, RawMove:
 			if isBlackMove != (p.game.pos.Turn() == Black) {
-				return &ParserError{
-					Message:  "move color mismatch",
-					Position: p.position,
+				if err := p.newError("move color mismatch"); !p.tolerate(err) {
+					return err
 				}
+				p.skipLine()
+				continue
 			}
 
 			move, err := p.parseMove()
 			if err != nil {
-				return err
+				if !p.tolerate(err) {
+					return err
+				}
+				p.skipLine()
+				continue
 			}
 
 			move.parent = p.currentMove
@@ -683,9 +826,8 @@ func (p *Parser) parseVariation(parentMoveNumber uint64, parentPly int) error {
 	}
 
 	if p.position >= len(p.tokens) {
-		return &ParserError{
-			Message:  "unterminated variation",
-			Position: p.position,
+		if err := p.newError("unterminated variation"); !p.tolerate(err) {
+			return err
 		}
 	}
 
@@ -699,6 +841,7 @@ func (p *Parser) parseVariation(parentMoveNumber uint64, parentPly int) error {
 }
 
 func (p *Parser) parseResult() {
+	p.foundResult = true
 	result := p.currentToken().Value
 	switch result {
 	case "1-0":
@@ -0,0 +1,293 @@
+package chess
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// The index file format starts with a magic string and a version, followed
+// by the indexed tag names and the entries. All integers are unsigned
+// varints and all strings are prefixed by their length.
+//
+//	magic    "PGNIDX"
+//	version  byte                                  pgnIndexVersion
+//	size     size of the indexed source in bytes
+//	tags     count, name*
+//	entries  count, (offset, length, line, column, value*)*
+//
+// Each entry has one value per indexed tag, empty if the game lacks it.
+
+const (
+	pgnIndexMagic   = "PGNIDX"
+	pgnIndexVersion = 1
+)
+
+// IndexEntry locates a game in a PGN source.
+type IndexEntry struct {
+	Tags   map[string]string // Values of the indexed tags present in the game
+	Offset int64             // Byte offset of the game in the source
+	Length int64             // Length of the game in bytes
+	Line   int               // Line of the start of the game, starting at 1
+	Column int               // Column of the start of the game, starting at 1
+}
+
+// PGNIndex records the location of every game of a PGN source along with
+// selected tag values, giving random access to the games of large files.
+// An index is built once with BuildPGNIndex and can be saved next to the
+// source with WriteTo and loaded back with ReadFrom.
+//
+// Example:
+//
+//	idx, err := chess.BuildPGNIndex(f, []string{"White", "Black", "Event"})
+//	if err != nil {
+//	    panic(err)
+//	}
+//	game, err := idx.ParseGame(f, 2000000)
+type PGNIndex struct {
+	Tags    []string     // Names of the indexed tags
+	Entries []IndexEntry // One entry per game, in source order
+	Size    int64        // Size of the indexed source in bytes, see Validate
+}
+
+// StaleIndexError is returned by PGNIndex.Validate when the source is not
+// the size it had when it was indexed, typically because it was modified.
+type StaleIndexError struct {
+	Indexed int64 // Size of the source when it was indexed
+	Actual  int64 // Current size of the source
+}
+
+func (e *StaleIndexError) Error() string {
+	return fmt.Sprintf("chess: stale pgn index: indexed source has %d bytes, got %d", e.Indexed, e.Actual)
+}
+
+// BuildPGNIndex scans the PGN source once and indexes its games along with
+// the values of the given tags. The scanner options are those of
+// NewScanner, e.g. WithEncoding for sources that aren't UTF-8; offsets and
+// lengths are always bytes of the source.
+func BuildPGNIndex(r io.Reader, tags []string, opts ...ScannerOption) (*PGNIndex, error) {
+	idx := &PGNIndex{Tags: tags}
+	scanner := NewScanner(r, opts...)
+	for {
+		game, err := scanner.ScanGame()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		entry := IndexEntry{
+			Offset: game.Offset,
+			Length: int64(len(scanner.scanner.Bytes())), // before decoding
+			Line:   game.Line,
+			Column: game.Column,
+		}
+		if len(tags) > 0 {
+			pairs := game.Tags()
+			entry.Tags = make(map[string]string, len(tags))
+			for _, tag := range tags {
+				if v, ok := pairs[tag]; ok {
+					entry.Tags[tag] = v
+				}
+			}
+		}
+		idx.Entries = append(idx.Entries, entry)
+	}
+	idx.Size = scanner.loc.offset
+	return idx, nil
+}
+
+// Validate checks that the index matches a source of the given size, such
+// as the size reported by os.File.Stat, and returns a *StaleIndexError
+// otherwise. The games of a stale index can't be read reliably. Only the
+// size is compared: a change preserving it is not detected.
+func (idx *PGNIndex) Validate(size int64) error {
+	if size != idx.Size {
+		return &StaleIndexError{Indexed: idx.Size, Actual: size}
+	}
+	return nil
+}
+
+// Len returns the number of indexed games.
+func (idx *PGNIndex) Len() int {
+	return len(idx.Entries)
+}
+
+// Find returns the indexes of the games whose tag has the given value.
+// The tag must be one of the indexed tags, otherwise nil is returned.
+func (idx *PGNIndex) Find(tag, value string) []int {
+	var found []int
+	for i, entry := range idx.Entries {
+		if v, ok := entry.Tags[tag]; ok && v == value {
+			found = append(found, i)
+		}
+	}
+	return found
+}
+
+// FindFunc returns the indexes of the games whose entry satisfies match.
+func (idx *PGNIndex) FindFunc(match func(IndexEntry) bool) []int {
+	var found []int
+	for i, entry := range idx.Entries {
+		if match(entry) {
+			found = append(found, i)
+		}
+	}
+	return found
+}
+
+// ReadGame reads game n from the indexed source with the given scanner
+// options.
+func (idx *PGNIndex) ReadGame(r io.ReaderAt, n int, opts ...ScannerOption) (*GameScanned, error) {
+	entry, err := idx.entry(n)
+	if err != nil {
+		return nil, err
+	}
+	scanner := idx.scannerAt(io.NewSectionReader(r, entry.Offset, entry.Length), n, opts)
+	game, err := scanner.ScanGame()
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return game, err
+}
+
+// ParseGame reads and parses game n from the indexed source with the
+// given scanner options. Parse errors are located in the source.
+func (idx *PGNIndex) ParseGame(r io.ReaderAt, n int, opts ...ScannerOption) (*Game, error) {
+	entry, err := idx.entry(n)
+	if err != nil {
+		return nil, err
+	}
+	scanner := idx.scannerAt(io.NewSectionReader(r, entry.Offset, entry.Length), n, opts)
+	return scanner.ParseNext()
+}
+
+// ScannerAt returns a scanner reading the indexed source from game n
+// to the end. Game indexes and locations reported by the scanner are
+// those of the whole source.
+func (idx *PGNIndex) ScannerAt(r io.ReaderAt, n int, opts ...ScannerOption) (*Scanner, error) {
+	entry, err := idx.entry(n)
+	if err != nil {
+		return nil, err
+	}
+	return idx.scannerAt(io.NewSectionReader(r, entry.Offset, math.MaxInt64-entry.Offset), n, opts), nil
+}
+
+func (idx *PGNIndex) scannerAt(r io.Reader, n int, opts []ScannerOption) *Scanner {
+	entry := idx.Entries[n]
+	scanner := NewScanner(r, opts...)
+	scanner.loc = scanLocation{offset: entry.Offset, line: entry.Line, column: entry.Column}
+	scanner.count = n
+	return scanner
+}
+
+func (idx *PGNIndex) entry(n int) (IndexEntry, error) {
+	if n < 0 || n >= len(idx.Entries) {
+		return IndexEntry{}, fmt.Errorf("chess: game %d out of range [0, %d)", n, len(idx.Entries))
+	}
+	return idx.Entries[n], nil
+}
+
+// WriteTo implements the io.WriterTo interface and writes the index in a
+// compact binary format.
+func (idx *PGNIndex) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	buf.WriteString(pgnIndexMagic)
+	buf.WriteByte(pgnIndexVersion)
+	writeUvarint(&buf, uint64(idx.Size))
+	writeUvarint(&buf, uint64(len(idx.Tags)))
+	for _, tag := range idx.Tags {
+		writeString(&buf, tag)
+	}
+	writeUvarint(&buf, uint64(len(idx.Entries)))
+	for _, entry := range idx.Entries {
+		writeUvarint(&buf, uint64(entry.Offset))
+		writeUvarint(&buf, uint64(entry.Length))
+		writeUvarint(&buf, uint64(entry.Line))
+		writeUvarint(&buf, uint64(entry.Column))
+		for _, tag := range idx.Tags {
+			writeString(&buf, entry.Tags[tag])
+		}
+	}
+	return buf.WriteTo(w)
+}
+
+// ReadFrom implements the io.ReaderFrom interface and reads an index
+// written by WriteTo, replacing the content of idx. The index is not
+// checked against its source: call Validate before reading games from a
+// source that may have changed since it was indexed.
+func (idx *PGNIndex) ReadFrom(r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	n := int64(len(data))
+	if err != nil {
+		return n, err
+	}
+	if !bytes.HasPrefix(data, []byte(pgnIndexMagic)) {
+		return n, errors.New("chess: not a pgn index")
+	}
+	br := bytes.NewReader(data[len(pgnIndexMagic):])
+	version, err := br.ReadByte()
+	if err != nil {
+		return n, io.ErrUnexpectedEOF
+	}
+	if version != pgnIndexVersion {
+		return n, fmt.Errorf("chess: unsupported pgn index version %d", version)
+	}
+
+	var decoded PGNIndex
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return n, io.ErrUnexpectedEOF
+	}
+	decoded.Size = int64(size)
+	count, err := binary.ReadUvarint(br)
+	if err != nil || count > uint64(br.Len()) {
+		return n, io.ErrUnexpectedEOF
+	}
+	for range count {
+		tag, err := readString(br)
+		if err != nil {
+			return n, err
+		}
+		decoded.Tags = append(decoded.Tags, tag)
+	}
+
+	count, err = binary.ReadUvarint(br)
+	if err != nil || count > uint64(br.Len()) {
+		return n, io.ErrUnexpectedEOF
+	}
+	decoded.Entries = make([]IndexEntry, 0, count)
+	for range count {
+		var fields [4]uint64
+		for i := range fields {
+			if fields[i], err = binary.ReadUvarint(br); err != nil {
+				return n, io.ErrUnexpectedEOF
+			}
+		}
+		entry := IndexEntry{
+			Offset: int64(fields[0]),
+			Length: int64(fields[1]),
+			Line:   int(fields[2]),
+			Column: int(fields[3]),
+		}
+		if len(decoded.Tags) > 0 {
+			entry.Tags = make(map[string]string, len(decoded.Tags))
+		}
+		for _, tag := range decoded.Tags {
+			value, err := readString(br)
+			if err != nil {
+				return n, err
+			}
+			if value != "" {
+				entry.Tags[tag] = value
+			}
+		}
+		decoded.Entries = append(decoded.Entries, entry)
+	}
+
+	*idx = decoded
+	return n, nil
+}
@@ -0,0 +1,183 @@
+package chess
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// indexFixture returns a PGN with n games whose White tag is "Player<i>".
+func indexFixture(n int) string {
+	var sb strings.Builder
+	for i := range n {
+		fmt.Fprintf(&sb, "[Event \"Index\"]\n[White \"Player%d\"]\n[Black \"Café\"]\n\n1. e4 e5 2. Nf3 Nc6 1-0\n\n", i)
+	}
+	return sb.String()
+}
+
+func TestBuildPGNIndex(t *testing.T) {
+	pgn := indexFixture(5) + "[Event \"Bad\"]\n[White \"Player5\"]\n\n1. e4 e5\n2. Ke3 *\n"
+	idx, err := BuildPGNIndex(strings.NewReader(pgn), []string{"White", "Site"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if idx.Len() != 6 {
+		t.Fatalf("expected 6 games, got %d", idx.Len())
+	}
+	if idx.Size != int64(len(pgn)) {
+		t.Errorf("expected size %d, got %d", len(pgn), idx.Size)
+	}
+
+	scanner := NewScanner(strings.NewReader(pgn))
+	for i := range idx.Len() {
+		expected, err := scanner.ScanGame()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		game, err := idx.ReadGame(strings.NewReader(pgn), i)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *game != *expected {
+			t.Errorf("expected game %d to be %+v, got %+v", i, expected, game)
+		}
+		if _, ok := idx.Entries[i].Tags["Site"]; ok {
+			t.Errorf("expected missing tags not to be indexed")
+		}
+	}
+
+	if found := idx.Find("White", "Player3"); !reflect.DeepEqual(found, []int{3}) {
+		t.Errorf("expected to find game 3, got %v", found)
+	}
+	if found := idx.Find("Black", "Café"); found != nil {
+		t.Errorf("expected tags that aren't indexed not to match, got %v", found)
+	}
+
+	game, err := idx.ParseGame(strings.NewReader(pgn), 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if game.GetTagPair("White") != "Player4" {
+		t.Errorf("expected game 4, got %s", game.GetTagPair("White"))
+	}
+
+	_, err = idx.ParseGame(strings.NewReader(pgn), 5)
+	var perr *ParserError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+	if perr.GameIndex != 5 || perr.Line != 35 || perr.Column != 4 {
+		t.Errorf("expected error in game 5 at 35:4, got %+v", perr)
+	}
+
+	if _, err := idx.ReadGame(strings.NewReader(pgn), 6); err == nil {
+		t.Errorf("expected an error for a game out of range")
+	}
+}
+
+func TestBuildPGNIndexEncoding(t *testing.T) {
+	game := "[Event \"Karlsbad\"]\n[Black \"R\xe9ti, Richard\"]\n\n1. e4 {Fran\xe7ais} e6 *\n\n"
+	pgn := game + game
+	idx, err := BuildPGNIndex(strings.NewReader(pgn), []string{"Black"}, WithEncoding(EncodingLatin1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if idx.Len() != 2 || idx.Entries[0].Tags["Black"] != "Réti, Richard" {
+		t.Fatalf("expected 2 decoded entries, got %+v", idx.Entries)
+	}
+	// the game is followed by a blank line; decoding would make it longer
+	if idx.Entries[1].Offset != int64(len(game)) || idx.Entries[0].Length != int64(len(game)-2) {
+		t.Errorf("expected lengths in source bytes, got %+v", idx.Entries)
+	}
+
+	scanned, err := idx.ReadGame(strings.NewReader(pgn), 1, WithEncoding(EncodingLatin1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(scanned.Raw, "Réti") {
+		t.Errorf("expected the game to be decoded, got %q", scanned.Raw)
+	}
+	g, err := idx.ParseGame(strings.NewReader(pgn), 1, WithEncoding(EncodingLatin1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Moves()[0].Comments() != "Français" {
+		t.Errorf("unexpected comment %q", g.Moves()[0].Comments())
+	}
+}
+
+func TestPGNIndexScannerAt(t *testing.T) {
+	pgn := indexFixture(10)
+	idx, err := BuildPGNIndex(strings.NewReader(pgn), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	scanner, err := idx.ScannerAt(strings.NewReader(pgn), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 7; i < 10; i++ {
+		game, err := scanner.ScanGame()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		entry := idx.Entries[i]
+		if game.Index != i || game.Offset != entry.Offset || game.Line != entry.Line {
+			t.Errorf("expected game %d at %d, got game %d at %d", i, entry.Offset, game.Index, game.Offset)
+		}
+		if !strings.Contains(game.Raw, fmt.Sprintf("Player%d\"", i)) {
+			t.Errorf("expected game %d, got %s", i, game.Raw)
+		}
+	}
+	if scanner.HasNext() {
+		t.Errorf("expected no more games")
+	}
+}
+
+func TestPGNIndexWriteRead(t *testing.T) {
+	pgn := indexFixture(20)
+	idx, err := BuildPGNIndex(strings.NewReader(pgn), []string{"White", "Black"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := idx.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	size := int64(buf.Len())
+	if n != size {
+		t.Errorf("expected %d bytes written, got %d", size, n)
+	}
+
+	var loaded PGNIndex
+	if n, err = loaded.ReadFrom(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != size {
+		t.Errorf("expected %d bytes read, got %d", size, n)
+	}
+	if !reflect.DeepEqual(idx, &loaded) {
+		t.Errorf("expected loaded index to equal the original")
+	}
+
+	if err := loaded.Validate(int64(len(pgn))); err != nil {
+		t.Errorf("unexpected error for the indexed source: %v", err)
+	}
+	var stale *StaleIndexError
+	if err := loaded.Validate(int64(len(pgn)) + 1); !errors.As(err, &stale) || stale.Indexed != int64(len(pgn)) {
+		t.Errorf("expected a StaleIndexError for a modified source, got %v", err)
+	}
+
+	if _, err := loaded.ReadFrom(strings.NewReader("not an index")); err == nil {
+		t.Errorf("expected an error for invalid data")
+	}
+	if _, err := loaded.ReadFrom(bytes.NewReader([]byte(pgnIndexMagic + "\x01\x05"))); err == nil {
+		t.Errorf("expected an error for truncated data")
+	}
+}
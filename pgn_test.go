@@ -353,8 +353,8 @@ func TestCompleteGame(t *testing.T) {
 		t.Fatalf("game move 4 is not correct, expected comment, got %s", moves[6].comments)
 	}
 
-	if moves[44].nag != "?!" {
-		t.Fatalf("game move 44 is not correct, expected nag '!?', got %s", moves[44].nag)
+	if moves[44].NAG() != "?!" {
+		t.Fatalf("game move 44 is not correct, expected nag '!?', got %s", moves[44].NAG())
 	}
 }
 
@@ -427,17 +427,17 @@ func TestParseMoveWithNAGAndComment(t *testing.T) {
 		t.Fatalf("expected at least 4 moves, got %d", len(moves))
 	}
 
-	if moves[0].nag == "" || moves[0].comments == "" {
-		t.Errorf("move 1 should have both NAG and comment, got nag: '%s', comment: '%s'", moves[0].nag, moves[0].comments)
+	if moves[0].NAG() == "" || moves[0].comments == "" {
+		t.Errorf("move 1 should have both NAG and comment, got nag: '%s', comment: '%s'", moves[0].NAG(), moves[0].comments)
 	}
-	if moves[1].nag == "" || moves[1].comments == "" {
-		t.Errorf("move 2 should have both NAG and comment, got nag: '%s', comment: '%s'", moves[1].nag, moves[1].comments)
+	if moves[1].NAG() == "" || moves[1].comments == "" {
+		t.Errorf("move 2 should have both NAG and comment, got nag: '%s', comment: '%s'", moves[1].NAG(), moves[1].comments)
 	}
-	if moves[2].nag == "" || moves[2].comments == "" {
-		t.Errorf("move 3 should have both NAG and comment, got nag: '%s', comment: '%s'", moves[2].nag, moves[2].comments)
+	if moves[2].NAG() == "" || moves[2].comments == "" {
+		t.Errorf("move 3 should have both NAG and comment, got nag: '%s', comment: '%s'", moves[2].NAG(), moves[2].comments)
 	}
-	if moves[3].nag == "" || moves[3].comments == "" {
-		t.Errorf("move 4 should have both NAG and comment, got nag: '%s', comment: '%s'", moves[3].nag, moves[3].comments)
+	if moves[3].NAG() == "" || moves[3].comments == "" {
+		t.Errorf("move 4 should have both NAG and comment, got nag: '%s', comment: '%s'", moves[3].NAG(), moves[3].comments)
 	}
 }
 
@@ -0,0 +1,421 @@
+package chess
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// exportLineWidth is the maximum line length of the PGN export format.
+const exportLineWidth = 80
+
+// sevenTagRoster lists the tags required by the PGN standard, in the
+// order of the export format, along with their default values.
+//
+//nolint:gochecknoglobals // lookup table.
+var sevenTagRoster = []sortableTagPair{
+	{Key: "Event", Value: "?"},
+	{Key: "Site", Value: "?"},
+	{Key: "Date", Value: "????.??.??"},
+	{Key: "Round", Value: "?"},
+	{Key: "White", Value: "?"},
+	{Key: "Black", Value: "?"},
+	{Key: "Result", Value: "*"},
+}
+
+// PGNWriter writes games as PGN with configurable formatting.
+// The zero configuration, as returned by NewPGNWriter without options,
+// writes every tag, comment, command, NAG and variation in Standard
+// Algebraic Notation on a single movetext line.
+//
+// Example:
+//
+//	w := NewPGNWriter(WithExportFormat(), WithoutComments())
+//	if err := w.WriteGame(os.Stdout, game); err != nil {
+//	    panic(err)
+//	}
+type PGNWriter struct {
+	notation        Encoder
+	lineWidth       int
+	exportFormat    bool
+	setUpTags       bool
+	stripComments   bool
+	stripVariations bool
+	stripNAGs       bool
+	nagStyle        nagStyle
+	stripCommands   bool
+}
+
+// nagStyle is how a PGNWriter writes Numeric Annotation Glyphs.
+type nagStyle uint8
+
+const (
+	nagAsRead   nagStyle = iota // as read or set on the move
+	nagSymbolic                 // symbols where available, $n otherwise
+	nagNumeric                  // always $n
+)
+
+// PGNWriterOption configures a PGNWriter.
+type PGNWriterOption func(*PGNWriter)
+
+// WithExportFormat produces the PGN standard export format: the Seven Tag
+// Roster is written first, in order and with default values for missing
+// tags, followed by the other tags sorted by name; the movetext is wrapped
+// at 80 columns, NAGs are written as $n since the export format doesn't
+// allow symbolic annotations, and games that don't start from the standard
+// position get SetUp and FEN tags.
+func WithExportFormat() PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.exportFormat = true
+		w.setUpTags = true
+		w.lineWidth = exportLineWidth
+		w.nagStyle = nagNumeric
+	}
+}
+
+// WithLineWidth wraps the movetext so that lines don't exceed n characters,
+// unless a single token is longer. A value of 0 disables wrapping.
+func WithLineWidth(n int) PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.lineWidth = n
+	}
+}
+
+// WithMoveNotation writes the moves with the given notation instead of
+// Standard Algebraic Notation, e.g. LongAlgebraicNotation{} or UCINotation{}.
+func WithMoveNotation(e Encoder) PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.notation = e
+	}
+}
+
+// WithSetUpTags writes SetUp and FEN tags describing the starting position
+// of games that don't start from the standard position.
+func WithSetUpTags() PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.setUpTags = true
+	}
+}
+
+// WithoutComments strips the text comments of the moves.
+func WithoutComments() PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.stripComments = true
+	}
+}
+
+// WithoutVariations writes only the main line of the games.
+func WithoutVariations() PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.stripVariations = true
+	}
+}
+
+// WithoutNAGs strips the Numeric Annotation Glyphs of the moves.
+func WithoutNAGs() PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.stripNAGs = true
+	}
+}
+
+// WithNAGSymbols writes the Numeric Annotation Glyphs of the moves as
+// their traditional symbols, such as "!?" or "±", when they have one that
+// reads back as the same glyph; the others are written as $n. Move
+// assessments from $1 to $6 are attached to the move, e.g. "Nf3!?".
+func WithNAGSymbols() PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.nagStyle = nagSymbolic
+	}
+}
+
+// WithNumericNAGs writes the Numeric Annotation Glyphs of the moves in the
+// $n form, as required by the PGN export format, including those read as
+// symbols such as "!?".
+func WithNumericNAGs() PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.nagStyle = nagNumeric
+	}
+}
+
+// WithoutCommands strips the commands embedded in comments, such as [%clk ...].
+func WithoutCommands() PGNWriterOption {
+	return func(w *PGNWriter) {
+		w.stripCommands = true
+	}
+}
+
+// NewPGNWriter returns a PGN writer configured with the given options.
+func NewPGNWriter(opts ...PGNWriterOption) *PGNWriter {
+	w := &PGNWriter{notation: AlgebraicNotation{}}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// WriteGame writes the PGN of the game to out, terminated by a newline.
+func (w *PGNWriter) WriteGame(out io.Writer, g *Game) error {
+	_, err := io.WriteString(out, w.String(g)+"\n")
+	return err
+}
+
+// String returns the PGN of the game.
+func (w *PGNWriter) String(g *Game) string {
+	var sb strings.Builder
+
+	tags := w.tags(g)
+	for _, tag := range tags {
+		sb.WriteString("[" + tag.Key + " \"" + escapeTagValue(tag.Value) + "\"]\n")
+	}
+	if len(tags) > 0 {
+		sb.WriteString("\n")
+	}
+
+	var toks []string
+	if g.rootMove != nil {
+		toks = w.appendAnnotations(toks, g.rootMove)
+		if len(g.rootMove.children) > 0 {
+			toks = w.appendLine(toks, g.rootMove, g.rootMove.children[0])
+		}
+	}
+	toks = append(toks, g.outcome.String())
+
+	lineLen := 0
+	for i, tok := range toks {
+		tokLen := utf8.RuneCountInString(tok)
+		if i > 0 {
+			if w.lineWidth > 0 && lineLen+1+tokLen > w.lineWidth {
+				sb.WriteString("\n")
+				lineLen = 0
+			} else {
+				sb.WriteString(" ")
+				lineLen++
+			}
+		}
+		sb.WriteString(tok)
+		lineLen += tokLen
+	}
+
+	return sb.String()
+}
+
+// tags returns the tag pairs of the game in output order.
+func (w *PGNWriter) tags(g *Game) []sortableTagPair {
+	values := make(map[string]string, len(g.tagPairs)+2)
+	for k, v := range g.tagPairs {
+		values[k] = v
+	}
+
+	if w.setUpTags && g.rootMove != nil {
+		start := g.rootMove.position
+		if !start.samePosition(StartingPosition()) || start.halfMoveClock != 0 || start.moveCount != 1 {
+			values["SetUp"] = "1"
+			values["FEN"] = start.String()
+		}
+	}
+
+	var tags []sortableTagPair
+	if w.exportFormat {
+		values["Result"] = g.outcome.String()
+		for _, str := range sevenTagRoster {
+			value, ok := values[str.Key]
+			if !ok {
+				value = str.Value
+			}
+			tags = append(tags, sortableTagPair{Key: str.Key, Value: value})
+			delete(values, str.Key)
+		}
+		rest := make([]sortableTagPair, 0, len(values))
+		for k, v := range values {
+			rest = append(rest, sortableTagPair{Key: k, Value: v})
+		}
+		slices.SortFunc(rest, func(a, b sortableTagPair) int {
+			return strings.Compare(a.Key, b.Key)
+		})
+		return append(tags, rest...)
+	}
+
+	for k, v := range values {
+		tags = append(tags, sortableTagPair{Key: k, Value: v})
+	}
+	slices.SortFunc(tags, cmpTags)
+	return tags
+}
+
+// appendLine appends the tokens of the line starting with move, played
+// from parent, and of the variations branching off it.
+func (w *PGNWriter) appendLine(toks []string, parent, move *Move) []string {
+	needNumber := true
+	for cur, m := parent, move; m != nil; {
+		pos := cur.position
+		if pos.turn == White {
+			toks = append(toks, fmt.Sprintf("%d.", pos.moveCount))
+		} else if needNumber {
+			toks = append(toks, fmt.Sprintf("%d...", pos.moveCount))
+		}
+		toks = append(toks, w.notation.Encode(pos, m))
+
+		toks = w.appendAnnotations(toks, m)
+		needNumber = w.hasComment(m)
+
+		if !w.stripVariations && m == cur.children[0] {
+			for _, alt := range cur.children[1:] {
+				variation := w.appendLine(nil, cur, alt)
+				variation[0] = "(" + variation[0]
+				variation[len(variation)-1] += ")"
+				toks = append(toks, variation...)
+				needNumber = true
+			}
+		}
+
+		cur, m = m, nil
+		if len(cur.children) > 0 {
+			m = cur.children[0]
+		}
+	}
+	return toks
+}
+
+// hasComment reports whether a brace comment is written after m.
+func (w *PGNWriter) hasComment(m *Move) bool {
+	return !w.stripComments && strings.TrimSpace(m.comments) != "" ||
+		!w.stripCommands && len(m.command) > 0
+}
+
+// appendAnnotations appends the NAGs, comment and commands of m.
+func (w *PGNWriter) appendAnnotations(toks []string, m *Move) []string {
+	if !w.stripNAGs {
+		toks = w.appendNAGs(toks, m)
+	}
+
+	var words []string
+	if !w.stripComments {
+		words = strings.Fields(m.comments)
+	}
+	if !w.stripCommands && len(m.command) > 0 {
+		keys := make([]string, 0, len(m.command))
+		for k := range m.command {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			words = append(words, strings.Fields("[%"+k+" "+m.command[k]+"]")...)
+		}
+	}
+	if len(words) == 0 {
+		return toks
+	}
+	// a comment can't contain its closing brace
+	for i, word := range words {
+		words[i] = strings.ReplaceAll(word, "}", "")
+	}
+	words = slices.DeleteFunc(words, func(word string) bool { return word == "" })
+	if len(words) == 0 {
+		return toks
+	}
+	words[0] = "{" + words[0]
+	words[len(words)-1] += "}"
+	return append(toks, words...)
+}
+
+// appendNAGs appends the NAGs of m in the style of the writer. The move
+// itself is the last token, if any: the root move has none.
+func (w *PGNWriter) appendNAGs(toks []string, m *Move) []string {
+	for i, s := range m.nags {
+		g, err := ParseGlyph(s)
+		switch {
+		case err != nil || w.nagStyle == nagAsRead:
+			toks = append(toks, s)
+		case w.nagStyle == nagNumeric || !hasOwnSymbol(g):
+			toks = append(toks, g.String())
+		case i == 0 && g.isMoveAssessment() && len(toks) > 0:
+			toks[len(toks)-1] += g.Symbol()
+		default:
+			toks = append(toks, g.Symbol())
+		}
+	}
+	return toks
+}
+
+// hasOwnSymbol reports whether g has a symbol that reads back as g. Symbols
+// shared by a White and a Black glyph read back as the White one.
+func hasOwnSymbol(g Glyph) bool {
+	n, err := ParseGlyph(g.Symbol())
+	return err == nil && n == g
+}
+
+// escapeTagValue escapes quotes and backslashes of a tag value.
+func escapeTagValue(s string) string {
+	if !strings.ContainsAny(s, `"\`) {
+		return s
+	}
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// PGNEncoder writes a stream of games as PGN to an underlying writer. It is
+// the counterpart of Scanner: games are written one at a time, separated by
+// a blank line, so arbitrarily large files can be produced without holding
+// the games in memory. Writes are buffered: Flush must be called once all
+// games have been encoded.
+//
+// Example:
+//
+//	enc := NewPGNEncoder(w, WithExportFormat())
+//	for scanner.HasNext() {
+//	    game, err := scanner.ParseNext()
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if err := enc.Encode(game); err != nil {
+//	        return err
+//	    }
+//	}
+//	return enc.Flush()
+type PGNEncoder struct {
+	err    error
+	w      *bufio.Writer
+	writer *PGNWriter
+	count  int
+}
+
+// NewPGNEncoder returns an encoder writing to w with a PGNWriter
+// configured with the given options.
+func NewPGNEncoder(w io.Writer, opts ...PGNWriterOption) *PGNEncoder {
+	return &PGNEncoder{
+		w:      bufio.NewWriter(w),
+		writer: NewPGNWriter(opts...),
+	}
+}
+
+// Encode writes the PGN of the game. Once an error occurred, every
+// following call returns that error.
+func (e *PGNEncoder) Encode(g *Game) error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.count > 0 {
+		if e.err = e.w.WriteByte('\n'); e.err != nil {
+			return e.err
+		}
+	}
+	e.err = e.writer.WriteGame(e.w, g)
+	e.count++
+	return e.err
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (e *PGNEncoder) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	e.err = e.w.Flush()
+	return e.err
+}
+
+// Count returns the number of games encoded so far.
+func (e *PGNEncoder) Count() int {
+	return e.count
+}
@@ -0,0 +1,283 @@
+package chess
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func mustLoadGame(t *testing.T, fname string) *Game {
+	t.Helper()
+	f, err := os.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	opt, err := PGN(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewGame(opt)
+}
+
+func TestPGNWriterDefault(t *testing.T) {
+	g := mustLoadGame(t, "fixtures/pgns/variations.pgn")
+	out := NewPGNWriter().String(g)
+	expected := "1. e4 (1. e3 e5) 1... e5 (1... d6 2. d4 Nf6 3. Nc3 e5 4. dxe5 (4. Nf3 Nbd7) 4... dxe5 5. Qxd8+ Kxd8) " +
+		"2. Nf3 (2. Nc3 Nf6 3. f4) 2... Nc6 3. d4 exd4 4. Nxd4 *"
+	if !strings.HasSuffix(out, "\n\n"+expected) {
+		t.Fatalf("unexpected movetext in\n%s", out)
+	}
+
+	opt, err := PGN(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameGame(t, g, NewGame(opt))
+}
+
+func TestPGNWriterExportFormat(t *testing.T) {
+	g := mustLoadGame(t, "fixtures/pgns/complete_game.pgn")
+	g.AddTagPair("Annotator", "Me")
+	g.RemoveTagPair("Site")
+	out := NewPGNWriter(WithExportFormat()).String(g)
+
+	header, movetext, found := strings.Cut(out, "\n\n")
+	if !found {
+		t.Fatalf("expected blank line after tags in\n%s", out)
+	}
+	tags := strings.Split(header, "\n")
+	for i, key := range []string{"Event", "Site", "Date", "Round", "White", "Black", "Result", "Annotator"} {
+		if !strings.HasPrefix(tags[i], "["+key+" ") {
+			t.Fatalf("expected tag %d to be %s but got %s", i, key, tags[i])
+		}
+	}
+	if tags[1] != `[Site "?"]` {
+		t.Fatalf("expected default Site tag but got %s", tags[1])
+	}
+	for _, line := range strings.Split(movetext, "\n") {
+		if utf8.RuneCountInString(line) > exportLineWidth {
+			t.Fatalf("line exceeds %d columns: %q", exportLineWidth, line)
+		}
+	}
+	if !strings.Contains(movetext, "\n") {
+		t.Fatalf("expected wrapped movetext")
+	}
+
+	opt, err := PGN(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewGame(opt); len(got.Moves()) != len(g.Moves()) {
+		t.Fatalf("expected %d moves but got %d", len(g.Moves()), len(got.Moves()))
+	}
+}
+
+func TestPGNWriterSetUpTags(t *testing.T) {
+	fen, err := FEN("6k1/5ppp/8/8/8/8/8/R6K w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame(fen)
+	if err := g.PushNotationMove("Ra8#", AlgebraicNotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	out := NewPGNWriter(WithSetUpTags()).String(g)
+	expected := "[FEN \"6k1/5ppp/8/8/8/8/8/R6K w - - 0 1\"]\n[SetUp \"1\"]\n\n1. Ra8# 1-0"
+	if out != expected {
+		t.Fatalf("expected\n%s\nbut got\n%s", expected, out)
+	}
+
+	if out := NewPGNWriter(WithSetUpTags()).String(NewGame()); out != "*" {
+		t.Fatalf("expected no setup tags for standard start but got %s", out)
+	}
+}
+
+func TestPGNWriterStripping(t *testing.T) {
+	opt, err := PGN(strings.NewReader(`[Event "Strip"]
+
+1. e4 $1 {good} (1. d4 d5) 1... e5 { [%clk 0:01:00] } 2. Nf3 *`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame(opt)
+
+	tests := []struct {
+		name     string
+		opts     []PGNWriterOption
+		expected string
+	}{
+		{"all", nil, "1. e4 $1 {good} (1. d4 d5) 1... e5 {[%clk 0:01:00]} 2. Nf3 *"},
+		{"comments", []PGNWriterOption{WithoutComments()}, "1. e4 $1 (1. d4 d5) 1... e5 {[%clk 0:01:00]} 2. Nf3 *"},
+		{"variations", []PGNWriterOption{WithoutVariations()}, "1. e4 $1 {good} 1... e5 {[%clk 0:01:00]} 2. Nf3 *"},
+		{"nags", []PGNWriterOption{WithoutNAGs()}, "1. e4 {good} (1. d4 d5) 1... e5 {[%clk 0:01:00]} 2. Nf3 *"},
+		{"commands", []PGNWriterOption{WithoutCommands()}, "1. e4 $1 {good} (1. d4 d5) 1... e5 2. Nf3 *"},
+		{"everything", []PGNWriterOption{WithoutComments(), WithoutVariations(), WithoutNAGs(), WithoutCommands()}, "1. e4 e5 2. Nf3 *"},
+		{"uci", []PGNWriterOption{WithMoveNotation(UCINotation{}), WithoutVariations(), WithoutCommands()}, "1. e2e4 $1 {good} 1... e7e5 2. g1f3 *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewPGNWriter(tt.opts...).String(g)
+			if !strings.HasSuffix(out, "\n\n"+tt.expected) {
+				t.Fatalf("expected movetext %q in\n%s", tt.expected, out)
+			}
+		})
+	}
+}
+
+func TestPGNWriterNAGStyles(t *testing.T) {
+	opt, err := PGN(strings.NewReader(`[Event "NAGs"]
+
+1. e4 $1 $14 e5 ?! 2. Nf3 $146 $200 *`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame(opt)
+
+	tests := []struct {
+		name     string
+		opts     []PGNWriterOption
+		expected string
+	}{
+		{"as read", nil, "1. e4 $1 $14 e5 ?! 2. Nf3 $146 $200 *"},
+		{"symbols", []PGNWriterOption{WithNAGSymbols()}, "1. e4! ⩲ e5?! 2. Nf3 N $200 *"},
+		{"numeric", []PGNWriterOption{WithNumericNAGs()}, "1. e4 $1 $14 e5 $6 2. Nf3 $146 $200 *"},
+		{"export format", []PGNWriterOption{WithExportFormat()}, "1. e4 $1 $14 e5 $6 2. Nf3 $146 $200 *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := NewPGNWriter(tt.opts...).String(g)
+			if !strings.HasSuffix(out, "\n\n"+tt.expected) {
+				t.Fatalf("expected movetext %q in\n%s", tt.expected, out)
+			}
+		})
+	}
+}
+
+func TestPGNWriterNAGSymbolsRoundTrip(t *testing.T) {
+	opt, err := PGN(strings.NewReader(`1. e4 $1 $14 e5 $13 2. Nf3 $5 $146 Nc6 $18 3. Bb5 $10 $36 $37 a6 $19 $16 *`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame(opt)
+	out := NewPGNWriter(WithNAGSymbols()).String(g)
+
+	opt, err = PGN(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("failed to parse %q: %v", out, err)
+	}
+	read := NewGame(opt)
+	if len(read.Moves()) != len(g.Moves()) {
+		t.Fatalf("expected %d moves, got %d in %q", len(g.Moves()), len(read.Moves()), out)
+	}
+	for i, m := range g.Moves() {
+		if got := read.Moves()[i].NAGs(); !slices.Equal(got, m.NAGs()) {
+			t.Errorf("move %d: expected %v, got %v in %q", i, m.NAGs(), got, out)
+		}
+	}
+}
+
+func TestPGNWriterRootNAG(t *testing.T) {
+	g := NewGame()
+	g.GetRootMove().SetNAG("$1")
+	if err := g.PushMove("e4", nil); err != nil {
+		t.Fatal(err)
+	}
+	out := NewPGNWriter(WithNAGSymbols()).String(g)
+	if !strings.HasSuffix(out, "! 1. e4 *") {
+		t.Fatalf("expected the root NAG as its own token in\n%s", out)
+	}
+}
+
+func TestPGNWriterCommentBrace(t *testing.T) {
+	g := NewGame()
+	if err := g.PushMove("e4", nil); err != nil {
+		t.Fatal(err)
+	}
+	g.Moves()[0].AddComment("see {1} and }")
+	out := NewPGNWriter().String(g)
+	if !strings.HasSuffix(out, "1. e4 {see {1 and} *") {
+		t.Fatalf("expected the closing braces to be stripped in\n%s", out)
+	}
+	opt, err := PGN(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := NewGame(opt).Moves()[0].Comments(); got != "see {1 and" {
+		t.Fatalf("unexpected comment %q", got)
+	}
+}
+
+func TestPGNWriterLineWidth(t *testing.T) {
+	g := mustLoadGame(t, "fixtures/pgns/complete_game.pgn")
+	out := NewPGNWriter(WithLineWidth(40)).String(g)
+	_, movetext, _ := strings.Cut(out, "\n\n")
+	for _, line := range strings.Split(movetext, "\n") {
+		if utf8.RuneCountInString(line) > 40 {
+			t.Fatalf("line exceeds 40 columns: %q", line)
+		}
+	}
+}
+
+func TestPGNEncoderRoundTrip(t *testing.T) {
+	f, err := os.Open("fixtures/pgns/multi_game.pgn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var sb strings.Builder
+	var games []*Game
+	enc := NewPGNEncoder(&sb)
+	scanner := NewScanner(f)
+	for scanner.HasNext() {
+		g, err := scanner.ParseNext()
+		if err != nil {
+			t.Fatal(err)
+		}
+		games = append(games, g)
+		if err := enc.Encode(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if enc.Count() != len(games) || len(games) < 2 {
+		t.Fatalf("expected %d encoded games but got %d", len(games), enc.Count())
+	}
+	out := sb.String()
+	if strings.Count(out, "\n\n[Event ") != len(games)-1 || !strings.HasSuffix(out, "\n") || strings.HasSuffix(out, "\n\n") {
+		t.Fatalf("unexpected game separation in\n%s", out)
+	}
+
+	scanner = NewScanner(strings.NewReader(out))
+	for i := 0; scanner.HasNext(); i++ {
+		g, err := scanner.ParseNext()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSameGame(t, games[i], g)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, os.ErrClosed
+}
+
+func TestPGNEncoderError(t *testing.T) {
+	enc := NewPGNEncoder(failingWriter{})
+	if err := enc.Encode(NewGame()); err != nil {
+		t.Fatalf("expected buffered write to succeed but got %v", err)
+	}
+	if err := enc.Flush(); err == nil {
+		t.Fatalf("expected flush error")
+	}
+	if err := enc.Encode(NewGame()); err == nil {
+		t.Fatalf("expected sticky error")
+	}
+}
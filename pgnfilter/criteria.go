@@ -0,0 +1,229 @@
+package pgnfilter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/corentings/chess/v2"
+)
+
+// Player matches games where the White or Black tag contains name,
+// ignoring case.
+func Player(name string) Criterion {
+	name = strings.ToLower(name)
+	return Tags(func(tags chess.TagPairs) bool {
+		return strings.Contains(strings.ToLower(tags["White"]), name) ||
+			strings.Contains(strings.ToLower(tags["Black"]), name)
+	})
+}
+
+// White matches games where the White tag contains name, ignoring case.
+func White(name string) Criterion {
+	name = strings.ToLower(name)
+	return Tags(func(tags chess.TagPairs) bool {
+		return strings.Contains(strings.ToLower(tags["White"]), name)
+	})
+}
+
+// Black matches games where the Black tag contains name, ignoring case.
+func Black(name string) Criterion {
+	name = strings.ToLower(name)
+	return Tags(func(tags chess.TagPairs) bool {
+		return strings.Contains(strings.ToLower(tags["Black"]), name)
+	})
+}
+
+// Elo matches games where both WhiteElo and BlackElo are in [minElo, maxElo].
+// Games with a missing or invalid rating don't match.
+func Elo(minElo, maxElo int) Criterion {
+	inRange := func(s string) bool {
+		elo, err := strconv.Atoi(s)
+		return err == nil && elo >= minElo && elo <= maxElo
+	}
+	return Tags(func(tags chess.TagPairs) bool {
+		return inRange(tags["WhiteElo"]) && inRange(tags["BlackElo"])
+	})
+}
+
+// DateRange matches games whose Date tag is in [from, to]. Dates use the
+// PGN format "YYYY.MM.DD"; an empty bound is open. Unknown month or day
+// parts, written "??", compare as "00". Games without a known year don't
+// match.
+func DateRange(from, to string) Criterion {
+	from, to = normalizeDate(from), normalizeDate(to)
+	return Tags(func(tags chess.TagPairs) bool {
+		date := tags["Date"]
+		if len(date) < 4 || strings.Contains(date[:4], "?") {
+			return false
+		}
+		date = normalizeDate(date)
+		return (from == "" || date >= from) && (to == "" || date <= to)
+	})
+}
+
+func normalizeDate(date string) string {
+	return strings.ReplaceAll(date, "?", "0")
+}
+
+// ECO matches games whose ECO tag matches one of the patterns. A pattern is
+// either a code ("B90"), a prefix ("B9" matches B90 to B99) or an inclusive
+// range ("A00-A39").
+func ECO(patterns ...string) Criterion {
+	return Tags(func(tags chess.TagPairs) bool {
+		eco := tags["ECO"]
+		if eco == "" {
+			return false
+		}
+		for _, pattern := range patterns {
+			if from, to, ok := strings.Cut(pattern, "-"); ok {
+				if eco >= from && eco <= to {
+					return true
+				}
+			} else if strings.HasPrefix(eco, pattern) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Result matches games whose Result tag is one of the outcomes.
+func Result(outcomes ...chess.Outcome) Criterion {
+	return Tags(func(tags chess.TagPairs) bool {
+		return slices.Contains(outcomes, chess.Outcome(tags["Result"]))
+	})
+}
+
+// Event matches games whose Event tag matches re.
+func Event(re *regexp.Regexp) Criterion {
+	return Tags(func(tags chess.TagPairs) bool {
+		return re.MatchString(tags["Event"])
+	})
+}
+
+// PlyCount matches games whose main line has between minPly and maxPly
+// half-moves, inclusive.
+func PlyCount(minPly, maxPly int) Criterion {
+	return Game(func(g *chess.Game) bool {
+		n := len(g.Moves())
+		return n >= minPly && n <= maxPly
+	})
+}
+
+// ReachesFEN matches games whose main line reaches the position described
+// by fen. Positions are compared on their placement, side to move, castling
+// rights and en passant square, ignoring the move clocks; the en passant
+// square only counts if an en passant capture is possible.
+func ReachesFEN(fen string) (Criterion, error) {
+	var target chess.Position
+	if err := target.UnmarshalText([]byte(fen)); err != nil {
+		return Criterion{}, err
+	}
+	key := positionKey(&target)
+	return Game(func(g *chess.Game) bool {
+		for _, pos := range g.Positions() {
+			if positionKey(pos) == key {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// positionKey returns the fields of the X-FEN of pos that identify it.
+func positionKey(pos *chess.Position) string {
+	fields := strings.Fields(pos.XFENString())
+	return strings.Join(fields[:4], " ")
+}
+
+// ReachesZobrist matches games whose main line reaches a position with the
+// given Polyglot Zobrist key, as computed by chess.ZobristHasher.
+func ReachesZobrist(key uint64) Criterion {
+	return Game(func(g *chess.Game) bool {
+		hasher := chess.NewZobristHasher()
+		for _, pos := range g.Positions() {
+			hash, err := hasher.HashPosition(pos.XFENString())
+			if err == nil && chess.ZobristHashToUint64(hash) == key {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Material matches games whose main line reaches a position with exactly
+// the given material. The signature lists the white pieces then the black
+// pieces separated by 'v', e.g. "KRPPvKR"; letters may appear in any order.
+func Material(signature string) (Criterion, error) {
+	white, black, ok := strings.Cut(strings.ToUpper(signature), "V")
+	if !ok {
+		return Criterion{}, fmt.Errorf("pgnfilter: invalid material signature %q", signature)
+	}
+	var target [2][7]int
+	for i, side := range []string{white, black} {
+		for _, r := range side {
+			pt := chess.PieceTypeFromString(string(r))
+			if pt == chess.NoPieceType {
+				return Criterion{}, fmt.Errorf("pgnfilter: invalid piece %q in material signature %q", r, signature)
+			}
+			target[i][pt]++
+		}
+	}
+	return Game(func(g *chess.Game) bool {
+		for _, pos := range g.Positions() {
+			if material(pos) == target {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// material counts the pieces of each type of both colors.
+func material(pos *chess.Position) [2][7]int {
+	var counts [2][7]int
+	for _, p := range pos.Board().SquareMap() {
+		side := 0
+		if p.Color() == chess.Black {
+			side = 1
+		}
+		counts[side][p.Type()]++
+	}
+	return counts
+}
+
+// Unique matches the first game of each distinct move sequence: games with
+// the same starting position and the same main line moves as a previous
+// game are rejected. Only the games accepted by the whole filter are
+// remembered, wherever Unique appears in it: a game rejected by another
+// criterion, or by a Not or Any wrapping Unique, doesn't hide later games.
+func Unique() Criterion {
+	seen := make(map[string]struct{})
+	return Criterion{
+		game: func(g *chess.Game) bool {
+			_, ok := seen[moveSequenceKey(g)]
+			return !ok
+		},
+		accept: []func(*chess.Game){func(g *chess.Game) {
+			seen[moveSequenceKey(g)] = struct{}{}
+		}},
+	}
+}
+
+// moveSequenceKey identifies the starting position and the main line
+// moves of a game.
+func moveSequenceKey(g *chess.Game) string {
+	var sb strings.Builder
+	positions := g.Positions()
+	if len(positions) > 0 {
+		sb.WriteString(positionKey(positions[0]))
+	}
+	for _, m := range g.Moves() {
+		sb.WriteByte(' ')
+		sb.WriteString(m.String())
+	}
+	return sb.String()
+}
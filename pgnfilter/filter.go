@@ -0,0 +1,180 @@
+// Package pgnfilter selects games from PGN sources, in the spirit of
+// pgn-extract. Games are matched by criteria on their tags, which are
+// checked on the raw game before it is parsed, and by criteria on their
+// moves and positions, which require the game to be parsed.
+//
+// Example:
+//
+//	f := pgnfilter.New(
+//	    pgnfilter.Player("Carlsen"),
+//	    pgnfilter.Elo(2700, 3000),
+//	    pgnfilter.ECO("B20-B99"),
+//	    pgnfilter.Unique(),
+//	)
+//	err := f.Scan(chess.NewScanner(r), func(pg chess.ParsedGame) error {
+//	    if pg.Err != nil {
+//	        return nil // skip games that can't be parsed
+//	    }
+//	    fmt.Println(pg.Game)
+//	    return nil
+//	})
+package pgnfilter
+
+import (
+	"errors"
+	"io"
+
+	"github.com/corentings/chess/v2"
+)
+
+// A Criterion is a condition on games. Criteria are built with the
+// functions of this package, such as Player or ReachesFEN.
+type Criterion struct {
+	tags   func(chess.TagPairs) bool
+	game   func(*chess.Game) bool
+	accept []func(*chess.Game) // called once the whole filter matched the game
+}
+
+// Tags returns a criterion matching games whose tag pairs satisfy fn.
+// It is checked before the game is parsed.
+func Tags(fn func(chess.TagPairs) bool) Criterion {
+	return Criterion{tags: fn}
+}
+
+// Game returns a criterion matching games that satisfy fn.
+// It requires the game to be parsed.
+func Game(fn func(*chess.Game) bool) Criterion {
+	return Criterion{game: fn}
+}
+
+// Not returns a criterion matching games that don't match c.
+func Not(c Criterion) Criterion {
+	if c.tags != nil {
+		return Tags(func(tags chess.TagPairs) bool { return !c.tags(tags) })
+	}
+	return Criterion{game: func(g *chess.Game) bool { return !c.game(g) }, accept: c.accept}
+}
+
+// Any returns a criterion matching games that match at least one of
+// the given criteria. It is checked before the game is parsed if all
+// the criteria are.
+func Any(criteria ...Criterion) Criterion {
+	onTags := true
+	var accept []func(*chess.Game)
+	for _, c := range criteria {
+		onTags = onTags && c.tags != nil
+		accept = append(accept, c.accept...)
+	}
+	if onTags {
+		return Tags(func(tags chess.TagPairs) bool {
+			for _, c := range criteria {
+				if c.tags(tags) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return Criterion{accept: accept, game: func(g *chess.Game) bool {
+		var tags chess.TagPairs
+		for _, c := range criteria {
+			if c.tags != nil {
+				if tags == nil {
+					tags = g.TagPairs()
+				}
+				if c.tags(tags) {
+					return true
+				}
+			} else if c.game(g) {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// A Filter matches games against a set of criteria, all of which must
+// match. A Filter holding a Unique criterion remembers the games it
+// matched, through Scan or Match, and must not be used concurrently.
+type Filter struct {
+	tags   []func(chess.TagPairs) bool
+	games  []func(*chess.Game) bool
+	accept []func(*chess.Game)
+}
+
+// New returns a filter matching the games that match all the criteria.
+func New(criteria ...Criterion) *Filter {
+	f := &Filter{}
+	for _, c := range criteria {
+		if c.tags != nil {
+			f.tags = append(f.tags, c.tags)
+		} else {
+			f.games = append(f.games, c.game)
+		}
+		f.accept = append(f.accept, c.accept...)
+	}
+	return f
+}
+
+// MatchTags reports whether the tag pairs match the criteria on tags.
+func (f *Filter) MatchTags(tags chess.TagPairs) bool {
+	for _, match := range f.tags {
+		if !match(tags) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match reports whether the game matches all the criteria.
+func (f *Filter) Match(g *chess.Game) bool {
+	if !f.MatchTags(g.TagPairs()) {
+		return false
+	}
+	return f.matchGame(g)
+}
+
+func (f *Filter) matchGame(g *chess.Game) bool {
+	for _, match := range f.games {
+		if !match(g) {
+			return false
+		}
+	}
+	for _, accept := range f.accept {
+		accept(g)
+	}
+	return true
+}
+
+// Scan reads the remaining games of the scanner and calls fn with those
+// matching the filter. The tags of each game are checked on the raw game
+// so that games rejected by them are never parsed. Games that can't be
+// parsed although their tags match are passed to fn with their error.
+// Scan stops at the first error returned by fn or by the scanner.
+func (f *Filter) Scan(s *chess.Scanner, fn func(chess.ParsedGame) error) error {
+	for {
+		scanned, err := s.ScanGame()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !f.MatchTags(scanned.Tags()) {
+			continue
+		}
+		game, err := s.Parse(scanned)
+		if err != nil {
+			if err := fn(chess.ParsedGame{Err: err, Index: scanned.Index}); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.matchGame(game) {
+			continue
+		}
+		if err := fn(chess.ParsedGame{Game: game, Index: scanned.Index}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package pgnfilter_test
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/corentings/chess/v2"
+	"github.com/corentings/chess/v2/pgnfilter"
+)
+
+type testGame struct {
+	white, black string
+	whiteElo     string
+	blackElo     string
+	date, eco    string
+	event        string
+	result       string
+	moves        string
+}
+
+//nolint:gochecknoglobals // test fixture.
+var testGames = []testGame{
+	{"Carlsen, Magnus", "Nakamura, Hikaru", "2850", "2780", "2020.05.01", "C65", "Online Blitz", "1-0", "1. e4 e5 2. Nf3 Nc6 3. Bb5 Nf6"},
+	{"Anand, Viswanathan", "Carlsen, Magnus", "2750", "2860", "2014.11.12", "D37", "World Championship", "1/2-1/2", "1. d4 Nf6 2. c4 e6 3. Nf3 d5"},
+	{"Amateur", "Beginner", "1500", "", "2019.??.??", "B20", "Club", "0-1", "1. e4 c5 2. Qh5 Qa5 3. Qxf7+ Kxf7"},
+	{"Carlsen, Magnus", "Nakamura, Hikaru", "2850", "2780", "2020.05.02", "C65", "Online Blitz", "1-0", "1. e4 e5 2. Nf3 Nc6 3. Bb5 Nf6"},
+	{"Kasparov, Garry", "Karpov, Anatoly", "2800", "2750", "????.??.??", "B44", "Candidates", "1-0", "1. e4 c5 2. Nf3 e6 3. d4 cxd4 4. Nxd4 Nc6"},
+}
+
+func testPGN() string {
+	var sb strings.Builder
+	for _, g := range testGames {
+		fmt.Fprintf(&sb, "[Event \"%s\"]\n[Date \"%s\"]\n[White \"%s\"]\n[Black \"%s\"]\n", g.event, g.date, g.white, g.black)
+		fmt.Fprintf(&sb, "[Result \"%s\"]\n[WhiteElo \"%s\"]\n[BlackElo \"%s\"]\n[ECO \"%s\"]\n\n", g.result, g.whiteElo, g.blackElo, g.eco)
+		fmt.Fprintf(&sb, "%s %s\n\n", g.moves, g.result)
+	}
+	return sb.String()
+}
+
+// scan returns the indexes of the games of testPGN matching the criteria.
+func scan(t *testing.T, criteria ...pgnfilter.Criterion) []int {
+	t.Helper()
+	var found []int
+	f := pgnfilter.New(criteria...)
+	err := f.Scan(chess.NewScanner(strings.NewReader(testPGN())), func(pg chess.ParsedGame) error {
+		if pg.Err != nil {
+			return pg.Err
+		}
+		found = append(found, pg.Index)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return found
+}
+
+func must(c pgnfilter.Criterion, err error) pgnfilter.Criterion {
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria []pgnfilter.Criterion
+		expected []int
+	}{
+		{"no criteria", nil, []int{0, 1, 2, 3, 4}},
+		{"player", []pgnfilter.Criterion{pgnfilter.Player("carlsen")}, []int{0, 1, 3}},
+		{"white", []pgnfilter.Criterion{pgnfilter.White("Carlsen")}, []int{0, 3}},
+		{"black", []pgnfilter.Criterion{pgnfilter.Black("Carlsen")}, []int{1}},
+		{"elo", []pgnfilter.Criterion{pgnfilter.Elo(2750, 2850)}, []int{0, 3, 4}},
+		{"date range", []pgnfilter.Criterion{pgnfilter.DateRange("2019.01.01", "2020.05.01")}, []int{0}},
+		{"open date range", []pgnfilter.Criterion{pgnfilter.DateRange("2019", "")}, []int{0, 2, 3}},
+		{"eco", []pgnfilter.Criterion{pgnfilter.ECO("C6", "B40-B49")}, []int{0, 3, 4}},
+		{"result", []pgnfilter.Criterion{pgnfilter.Result(chess.Draw, chess.BlackWon)}, []int{1, 2}},
+		{"event", []pgnfilter.Criterion{pgnfilter.Event(regexp.MustCompile(`(?i)^(world|candidates)`))}, []int{1, 4}},
+		{"ply count", []pgnfilter.Criterion{pgnfilter.PlyCount(7, 10)}, []int{4}},
+		{"not", []pgnfilter.Criterion{pgnfilter.Not(pgnfilter.Player("Carlsen"))}, []int{2, 4}},
+		{"any", []pgnfilter.Criterion{pgnfilter.Any(pgnfilter.Black("Carlsen"), pgnfilter.PlyCount(8, 8))}, []int{1, 4}},
+		{"unique", []pgnfilter.Criterion{pgnfilter.Unique()}, []int{0, 1, 2, 4}},
+		{"unique after other criteria", []pgnfilter.Criterion{pgnfilter.Unique(), pgnfilter.DateRange("2020.05.02", "")}, []int{3}},
+		{
+			"reaches fen",
+			[]pgnfilter.Criterion{must(pgnfilter.ReachesFEN("r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - 9 9"))},
+			[]int{0, 3},
+		},
+		{"reaches zobrist", []pgnfilter.Criterion{pgnfilter.ReachesZobrist(0x463b96181691fc9c)}, []int{0, 1, 2, 3, 4}},
+		{"material", []pgnfilter.Criterion{must(pgnfilter.Material("KQRRBBNNPPPPPPPvKQRRBBNNPPPPPPP"))}, []int{4}},
+		{"material without queen", []pgnfilter.Criterion{must(pgnfilter.Material("kppppppppnnbbrrVkqrrbbnnppppppp"))}, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scan(t, tt.criteria...); !slices.Equal(got, tt.expected) {
+				t.Errorf("expected games %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFilterSkipsParsing(t *testing.T) {
+	pgn := "[Event \"?\"]\n[White \"A\"]\n\n1. e4 e5 2. Ke3 1-0\n\n[Event \"?\"]\n[White \"B\"]\n\n1. e4 e5 1-0\n"
+	f := pgnfilter.New(pgnfilter.White("B"))
+	var found []int
+	err := f.Scan(chess.NewScanner(strings.NewReader(pgn)), func(pg chess.ParsedGame) error {
+		if pg.Err != nil {
+			return pg.Err
+		}
+		found = append(found, pg.Index)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected the invalid game not to be parsed, got %v", err)
+	}
+	if !slices.Equal(found, []int{1}) {
+		t.Errorf("expected game 1, got %v", found)
+	}
+
+	errStop := errors.New("stop")
+	err = pgnfilter.New().Scan(chess.NewScanner(strings.NewReader(pgn)), func(pg chess.ParsedGame) error {
+		var perr *chess.ParserError
+		if errors.As(pg.Err, &perr) {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("expected the invalid game to be reported, got %v", err)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	game := chess.NewGame()
+	game.AddTagPair("White", "Carlsen, Magnus")
+	_ = game.PushMove("e4", nil)
+
+	if !pgnfilter.New(pgnfilter.Player("carlsen"), pgnfilter.PlyCount(1, 1)).Match(game) {
+		t.Errorf("expected the game to match")
+	}
+	if pgnfilter.New(pgnfilter.Player("carlsen"), pgnfilter.PlyCount(2, 5)).Match(game) {
+		t.Errorf("expected the game not to match")
+	}
+	if !pgnfilter.New(pgnfilter.Player("carlsen")).MatchTags(game.TagPairs()) {
+		t.Errorf("expected the tags to match")
+	}
+}
+
+func TestUniqueRemembersAcceptedGames(t *testing.T) {
+	game := chess.NewGame()
+	_ = game.PushMove("e4", nil)
+
+	unique := pgnfilter.Unique()
+	if pgnfilter.New(pgnfilter.Not(unique), pgnfilter.PlyCount(2, 5)).Match(game) {
+		t.Fatalf("expected the game not to match")
+	}
+	if pgnfilter.New(pgnfilter.Not(pgnfilter.Any(unique, pgnfilter.White("Nobody")))).Match(game) {
+		t.Fatalf("expected the game not to match")
+	}
+	// the game was rejected both times, so unique must not have seen it
+	f := pgnfilter.New(unique)
+	if !f.Match(game) {
+		t.Errorf("expected a rejected game not to be remembered")
+	}
+	if f.Match(game) {
+		t.Errorf("expected an accepted game to be remembered")
+	}
+}
+
+func TestMaterialInvalid(t *testing.T) {
+	for _, sig := range []string{"KQK", "KXvK"} {
+		if _, err := pgnfilter.Material(sig); err == nil {
+			t.Errorf("expected an error for %q", sig)
+		}
+	}
+	if _, err := pgnfilter.ReachesFEN("not a fen"); err == nil {
+		t.Errorf("expected an error for an invalid FEN")
+	}
+}
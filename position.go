@@ -154,6 +154,11 @@ func (pos *Position) Status() Method {
 	return engine{}.Status(pos)
 }
 
+// InCheck reports whether the side to move is in check.
+func (pos *Position) InCheck() bool {
+	return isInCheck(pos)
+}
+
 // Board returns the position's board.
 func (pos *Position) Board() *Board {
 	return pos.board
@@ -221,38 +226,97 @@ func (pos *Position) XFENString() string {
 	t := pos.turn.String()
 	c := pos.castleRights.String()
 	sq := "-"
-	if pos.enPassantSquare != NoSquare {
-		// Check if there is a pawn in a position to capture en passant
-		var rank Rank
-		if pos.turn == White {
-			rank = Rank5
-		} else {
-			rank = Rank4
+	if pos.capturableEnPassant() {
+		sq = pos.enPassantSquare.String()
+	}
+	return fmt.Sprintf("%s %s %s %s %d %d", b, t, c, sq, pos.halfMoveClock, pos.moveCount)
+}
+
+// capturableEnPassant reports whether the en passant square is set and a
+// pawn of the side to move stands next to the pushed pawn to capture it.
+func (pos *Position) capturableEnPassant() bool {
+	if pos.enPassantSquare == NoSquare {
+		return false
+	}
+	// The pawns that could capture en passant are on the rank of the pushed
+	// pawn, on a file adjacent to the en passant square
+	rank := Rank4
+	if pos.turn == White {
+		rank = Rank5
+	}
+	file := pos.enPassantSquare.File()
+	for _, f := range []File{file - 1, file + 1} {
+		if f < FileA || f > FileH {
+			continue
 		}
-		// The en passant target square will always be on the rank opposite the current turn's pawns
-		file := pos.enPassantSquare.File()
-		potentialPawnFiles := []File{file - 1, file + 1} // Pawns that could capture en passant will be on an adjacent file
-
-		for _, f := range potentialPawnFiles {
-			if f < FileA || f > FileH { // Ensure file is within bounds
-				continue
-			}
-
-			potentialPawnSquare := NewSquare(f, rank)
-			potentialPawn := pos.board.Piece(potentialPawnSquare)
-			if potentialPawn == NoPiece {
-				continue
-			}
-			if potentialPawn.Type() != Pawn {
-				continue
-			}
-			if potentialPawn.Color() == pos.turn {
-				sq = pos.enPassantSquare.String()
-				break
-			}
+		if p := pos.board.Piece(NewSquare(f, rank)); p.Type() == Pawn && p.Color() == pos.turn {
+			return true
 		}
 	}
-	return fmt.Sprintf("%s %s %s %s %d %d", b, t, c, sq, pos.halfMoveClock, pos.moveCount)
+	return false
+}
+
+// Mirror returns the position with the colors swapped: the board is
+// flipped over the horizontal center line, white pieces become black ones
+// and vice versa, and the side to move, the castle rights and the en passant
+// square are swapped accordingly. The clocks are kept. The evaluation of the
+// mirrored position for the side to move is the same as the original one.
+//
+// Example:
+//
+//	var pos chess.Position
+//	_ = pos.UnmarshalText([]byte("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"))
+//	fmt.Println(pos.Mirror())
+//	// rnbqkbnr/pppp1ppp/8/4p3/8/8/PPPPPPPP/RNBQKBNR w KQkq e6 0 1
+func (pos *Position) Mirror() *Position {
+	m := make(map[Square]Piece, pieceMapSize)
+	for sq := range Square(numOfSquaresInBoard) {
+		if p := pos.board.Piece(sq); p != NoPiece {
+			m[NewSquare(sq.File(), Rank8-sq.Rank())] = NewPiece(p.Type(), p.Color().Other())
+		}
+	}
+
+	var rights strings.Builder
+	for i, r := range castleRightSquares {
+		// the right of the other color on the same wing
+		other := castleRightSquares[(i+2)%len(castleRightSquares)]
+		if strings.Contains(string(pos.castleRights), other.right) {
+			rights.WriteString(r.right)
+		}
+	}
+	cr := CastleRights("-")
+	if rights.Len() > 0 {
+		cr = CastleRights(rights.String())
+	}
+
+	ep := NoSquare
+	if pos.enPassantSquare != NoSquare {
+		ep = NewSquare(pos.enPassantSquare.File(), Rank8-pos.enPassantSquare.Rank())
+	}
+	return &Position{
+		board:           NewBoard(m),
+		turn:            pos.turn.Other(),
+		castleRights:    cr,
+		enPassantSquare: ep,
+		halfMoveClock:   pos.halfMoveClock,
+		moveCount:       pos.moveCount,
+		inCheck:         pos.inCheck,
+	}
+}
+
+// FlipHorizontal returns the position flipped over the vertical center
+// line, e.g. a king on b2 goes to g2. Only pawnless positions keep the
+// same meaning once flipped, an error is returned for the others. Castle
+// rights are dropped since the kings leave the e-file.
+func (pos *Position) FlipHorizontal() (*Position, error) {
+	if pos.board.bbWhitePawn|pos.board.bbBlackPawn != 0 {
+		return nil, errors.New("chess: can't flip a position with pawns horizontally")
+	}
+	cp := pos.copy()
+	cp.board = pos.board.Flip(LeftRight)
+	cp.castleRights = "-"
+	cp.enPassantSquare = NoSquare
+	return cp, nil
 }
 
 // Hash returns a unique hash of the position.
@@ -85,3 +85,64 @@ func TestPositionPly(t *testing.T) {
 		}
 	}
 }
+
+func TestPositionMirror(t *testing.T) {
+	tests := []struct {
+		fen      string
+		expected string
+	}{
+		{
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+			"rnbqkbnr/pppp1ppp/8/4p3/8/8/PPPPPPPP/RNBQKBNR w KQkq e6 0 1",
+		},
+		{
+			"r3k3/8/8/8/8/8/6q1/4K2R w Kq - 3 20",
+			"4k2r/6Q1/8/8/8/8/8/R3K3 b Qk - 3 20",
+		},
+		{
+			"4k3/8/8/8/8/8/8/4K3 w - - 0 1",
+			"4k3/8/8/8/8/8/8/4K3 b - - 0 1",
+		},
+	}
+	for _, test := range tests {
+		pos := unsafeFEN(test.fen)
+		mirrored := pos.Mirror()
+		if mirrored.String() != test.expected {
+			t.Errorf("expected the mirror of %s to be %s, got %s", test.fen, test.expected, mirrored)
+		}
+		if len(pos.ValidMoves()) != len(mirrored.ValidMoves()) {
+			t.Errorf("expected %s and its mirror to have the same number of moves", test.fen)
+		}
+		if mirrored.Mirror().String() != test.fen {
+			t.Errorf("expected mirroring %s twice to give it back", test.fen)
+		}
+	}
+}
+
+func TestPositionFlipHorizontal(t *testing.T) {
+	pos := unsafeFEN("r3k3/8/8/8/8/2Q5/8/R3K3 w Qq - 0 1")
+	flipped, err := pos.FlipHorizontal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = "3k3r/8/8/8/8/5Q2/8/3K3R w - - 0 1"
+	if flipped.String() != expected {
+		t.Errorf("expected %s, got %s", expected, flipped)
+	}
+	if pos.String() != "r3k3/8/8/8/8/2Q5/8/R3K3 w Qq - 0 1" {
+		t.Errorf("expected the position to be unchanged, got %s", pos)
+	}
+
+	if _, err := StartingPosition().FlipHorizontal(); err == nil {
+		t.Error("expected an error for a position with pawns")
+	}
+}
+
+func TestPositionInCheck(t *testing.T) {
+	if StartingPosition().InCheck() {
+		t.Error("expected the starting position not to be in check")
+	}
+	if !unsafeFEN("4k3/8/8/8/8/8/8/4R1K1 b - - 0 1").InCheck() {
+		t.Error("expected black to be in check")
+	}
+}
@@ -0,0 +1,108 @@
+package chess
+
+// ReplayRecord describes a single ply of a game along with the positions
+// around it and its annotations.
+type ReplayRecord struct {
+	Before     *Position         // Position before the move
+	After      *Position         // Position after the move
+	Move       *Move             // The move itself
+	Commands   map[string]string // Commands of the move, such as %clk or %eval
+	SAN        string            // Move in Standard Algebraic Notation
+	UCI        string            // Move in UCI notation
+	Comments   string            // Comments following the move
+	NAGs       []Glyph           // Numeric Annotation Glyphs of the move
+	Ply        int               // Half-move number, starting at 1 for white's first move
+	MoveNumber int               // Full move number
+	Depth      int               // Variation nesting level, 0 for the main line
+}
+
+// Replay is a cursor over the moves of a game. It is created by
+// Game.Replay or Game.ReplayAll and advanced with Next.
+//
+// Example:
+//
+//	replay := game.Replay()
+//	for replay.Next() {
+//	    rec := replay.Record()
+//	    fmt.Println(rec.MoveNumber, rec.SAN, rec.Comments)
+//	}
+type Replay struct {
+	stack   []replayNode
+	current ReplayRecord
+	all     bool
+}
+
+type replayNode struct {
+	move  *Move
+	depth int
+}
+
+// Replay returns a cursor over the main line of the game.
+func (g *Game) Replay() *Replay {
+	return newReplay(g.rootMove, false)
+}
+
+// ReplayAll returns a cursor over all the moves of the game including
+// variations. Moves are visited depth first: each move is followed by its
+// continuation, and variations are visited once the line they branch off
+// has been exhausted.
+func (g *Game) ReplayAll() *Replay {
+	return newReplay(g.rootMove, true)
+}
+
+func newReplay(root *Move, all bool) *Replay {
+	r := &Replay{all: all}
+	r.push(root, 0)
+	return r
+}
+
+// push schedules the children of m to be visited.
+func (r *Replay) push(m *Move, depth int) {
+	if len(m.children) == 0 {
+		return
+	}
+	if r.all {
+		// push in reverse so that the main line is visited first
+		for i := len(m.children) - 1; i > 0; i-- {
+			r.stack = append(r.stack, replayNode{move: m.children[i], depth: depth + 1})
+		}
+	}
+	r.stack = append(r.stack, replayNode{move: m.children[0], depth: depth})
+}
+
+// Next advances the cursor to the next move and reports whether there was one.
+func (r *Replay) Next() bool {
+	if len(r.stack) == 0 {
+		return false
+	}
+	node := r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+	r.push(node.move, node.depth)
+
+	m := node.move
+	before := m.parent.position
+	ply := (before.moveCount-1)*2 + 1
+	if before.turn == Black {
+		ply++
+	}
+	r.current = ReplayRecord{
+		Before:     before,
+		After:      m.position,
+		Move:       m,
+		Commands:   m.command,
+		SAN:        AlgebraicNotation{}.Encode(before, m),
+		UCI:        UCINotation{}.Encode(before, m),
+		Comments:   m.comments,
+		NAGs:       m.NAGs(),
+		Ply:        ply,
+		MoveNumber: before.moveCount,
+		Depth:      node.depth,
+	}
+	return true
+}
+
+// Record returns the record of the current move. It must only be
+// called after a call to Next returned true.
+func (r *Replay) Record() ReplayRecord {
+	return r.current
+}
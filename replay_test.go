@@ -0,0 +1,78 @@
+package chess
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReplayMainline(t *testing.T) {
+	opt, err := PGN(strings.NewReader(`[Event "Replay"]
+
+1. e4 {best by test} e5 $1 (1... c5 2. Nf3) 2. Nf3 { [%clk 0:01:00] } *`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame(opt)
+
+	var sans []string
+	replay := g.Replay()
+	for replay.Next() {
+		rec := replay.Record()
+		sans = append(sans, rec.SAN)
+		if rec.Depth != 0 {
+			t.Fatalf("expected main line depth 0 but got %d", rec.Depth)
+		}
+		if rec.After != rec.Move.Position() || rec.Before != rec.Move.Parent().Position() {
+			t.Fatalf("positions of %s do not match the move tree", rec.SAN)
+		}
+	}
+	if strings.Join(sans, " ") != "e4 e5 Nf3" {
+		t.Fatalf("unexpected main line %v", sans)
+	}
+
+	replay = g.Replay()
+	replay.Next()
+	first := replay.Record()
+	if first.Ply != 1 || first.MoveNumber != 1 || first.UCI != "e2e4" || first.Comments != "best by test" {
+		t.Fatalf("unexpected first record %+v", first)
+	}
+	replay.Next()
+	second := replay.Record()
+	if second.Ply != 2 || second.MoveNumber != 1 || !slices.Equal(second.NAGs, []Glyph{GlyphGoodMove}) {
+		t.Fatalf("unexpected second record %+v", second)
+	}
+	replay.Next()
+	third := replay.Record()
+	if third.Ply != 3 || third.MoveNumber != 2 || third.Commands["clk"] != "0:01:00" {
+		t.Fatalf("unexpected third record %+v", third)
+	}
+	if replay.Next() {
+		t.Fatalf("expected end of replay")
+	}
+}
+
+func TestReplayAll(t *testing.T) {
+	opt, err := PGN(strings.NewReader(`1. e4 e5 (1... c5 2. Nf3 (2. Nc3)) 2. Nf3 *`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame(opt)
+
+	var got []string
+	replay := g.ReplayAll()
+	for replay.Next() {
+		rec := replay.Record()
+		got = append(got, rec.SAN+":"+string(rune('0'+rec.Depth)))
+	}
+	expected := "e4:0 e5:0 Nf3:0 c5:1 Nf3:1 Nc3:2"
+	if strings.Join(got, " ") != expected {
+		t.Fatalf("expected %s but got %s", expected, strings.Join(got, " "))
+	}
+}
+
+func TestReplayEmptyGame(t *testing.T) {
+	if NewGame().Replay().Next() {
+		t.Fatalf("expected no moves")
+	}
+}
@@ -24,13 +24,39 @@ package chess
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"io"
+	"strings"
+	"unicode/utf8"
 )
 
 // GameScanned represents a complete chess game in PGN format.
 type GameScanned struct {
 	// Raw contains the complete PGN text of the game
 	Raw string
+	// Index is the index of the game in the Scanner, starting at 0
+	Index int
+	// Offset is the byte offset of the game in the source
+	Offset int64
+	// Line and Column locate the start of the game in the source,
+	// starting at 1
+	Line   int
+	Column int
+}
+
+// Tags returns the tag pairs of the game header without parsing the
+// movetext. Reading stops at the first malformed tag pair.
+func (g *GameScanned) Tags() TagPairs {
+	tags := make(TagPairs)
+	lexer := NewLexer(g.Raw)
+	for lexer.NextToken().Type == TagStart {
+		key, value, end := lexer.NextToken(), lexer.NextToken(), lexer.NextToken()
+		if key.Type != TagKey || value.Type != TagValue || end.Type != TagEnd {
+			break
+		}
+		tags[key.Value] = value.Value
+	}
+	return tags
 }
 
 // TokenizeGame converts a PGN game into a sequence of tokens.
@@ -50,7 +76,11 @@ func TokenizeGame(game *GameScanned) ([]Token, error) {
 		return nil, nil
 	}
 
-	lexer := NewLexer(game.Raw)
+	return tokenize(NewLexer(game.Raw)), nil
+}
+
+// tokenize returns the tokens read by the lexer until the end of its input.
+func tokenize(lexer *Lexer) []Token {
 	var tokens []Token
 
 	for {
@@ -61,7 +91,7 @@ func TokenizeGame(game *GameScanned) ([]Token, error) {
 		tokens = append(tokens, token)
 	}
 
-	return tokens, nil
+	return tokens
 }
 
 // Scanner provides functionality to read chess games from a PGN source.
@@ -73,6 +103,30 @@ type Scanner struct {
 	nextGame        *GameScanned // Buffer for peeked game
 	nextParsedGames []*Game      // only valid when ExpandVariations==true
 	opts            ScannerOpts
+	loc             scanLocation // location of the data consumed so far
+	tokenLoc        scanLocation // location of the last scanned game
+	count           int          // number of games scanned
+}
+
+// scanLocation is a location in the source of a Scanner.
+type scanLocation struct {
+	offset int64
+	line   int
+	column int
+}
+
+// advance moves the location past data.
+func (l *scanLocation) advance(data []byte) {
+	for _, c := range data {
+		switch {
+		case c == '\n':
+			l.line++
+			l.column = 1
+		case utf8.RuneStart(c):
+			l.column++
+		}
+	}
+	l.offset += int64(len(data))
 }
 
 type ScannerOption func(*Scanner)
@@ -86,24 +140,58 @@ func WithExpandVariations() ScannerOption {
 	}
 }
 
+// WithLenientParsing() instructs the scanner to parse games in lenient mode:
+// recoverable problems are repaired or skipped and recorded as warnings on
+// the returned games instead of failing the whole game. See WithLenient.
+func WithLenientParsing() ScannerOption {
+	return func(s *Scanner) {
+		s.opts.Lenient = true
+	}
+}
+
+// WithEncoding() instructs the scanner to decode the source from the given
+// character encoding, e.g. EncodingLatin1 for historical PGN files. With any
+// encoding but EncodingUTF8 the text of the scanned games, and so the tag
+// values and comments of the parsed games, is valid UTF-8. Offsets within a
+// decoded game count bytes of the decoded text.
+func WithEncoding(enc Encoding) ScannerOption {
+	return func(s *Scanner) {
+		s.opts.Encoding = enc
+	}
+}
+
+// WithNotation() instructs the scanner to read the moves of the movetext
+// with the given notation instead of SAN, e.g. DescriptiveNotation{} for
+// old literature. See WithRawMoves.
+func WithNotation(d Decoder) ScannerOption {
+	return func(s *Scanner) {
+		s.opts.Notation = d
+	}
+}
+
 type ScannerOpts struct {
-	ExpandVariations bool // default false
+	ExpandVariations bool     // default false
+	Lenient          bool     // default false
+	Encoding         Encoding // default EncodingUTF8
+	Notation         Decoder  // default nil, for SAN
 }
 
 // NewScanner creates a new PGN scanner that reads from the provided reader.
 // The scanner is configured to properly split PGN games and handle
-// PGN-specific syntax.
+// PGN-specific syntax. A UTF-8 byte order mark at the start of the source
+// is skipped.
 //
 // Example:
 //
 //	scanner := NewScanner(strings.NewReader(pgnText))
 func NewScanner(r io.Reader, opts ...ScannerOption) *Scanner {
 	s := bufio.NewScanner(r)
-	s.Split(splitPGNGames)
 	ret := &Scanner{
 		scanner:         s,
 		nextParsedGames: make([]*Game, 0),
+		loc:             scanLocation{line: 1, column: 1},
 	}
+	s.Split(ret.split)
 
 	// apply all the options
 	for _, opt := range opts {
@@ -133,7 +221,7 @@ func (s *Scanner) ScanGame() (*GameScanned, error) {
 
 	// Otherwise scan the next game
 	if s.scanner.Scan() {
-		return &GameScanned{Raw: s.scanner.Text()}, nil
+		return s.scanned(), nil
 	}
 
 	// Check for errors
@@ -161,7 +249,7 @@ func (s *Scanner) HasNext() bool {
 	// Try to scan the next game
 	if s.scanner.Scan() {
 		// Store the game in the buffer
-		s.nextGame = &GameScanned{Raw: s.scanner.Text()}
+		s.nextGame = s.scanned()
 		return true
 	}
 
@@ -191,12 +279,7 @@ func (s *Scanner) ParseNext() (*Game, error) {
 	if err != nil {
 		return nil, err
 	}
-	tokens, err := TokenizeGame(scannedGame)
-	if err != nil {
-		return nil, err
-	}
-	parser := NewParser(tokens)
-	game, err := parser.Parse()
+	game, err := s.parseScanned(scannedGame)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +292,94 @@ func (s *Scanner) ParseNext() (*Game, error) {
 	return parsedGames[0], nil
 }
 
+// Parse tokenizes and parses a game returned by ScanGame with the options
+// of the scanner, letting callers inspect the raw game before deciding to
+// parse it. Variations are not expanded.
+func (s *Scanner) Parse(game *GameScanned) (*Game, error) {
+	return s.parseScanned(game)
+}
+
+// parseScanned tokenizes and parses a scanned game with the options of
+// the scanner. It is safe for concurrent use.
+func (s *Scanner) parseScanned(scannedGame *GameScanned) (*Game, error) {
+	var parserOpts []ParserOption
+	if s.opts.Lenient {
+		parserOpts = append(parserOpts, WithLenient())
+	}
+	var tokens []Token
+	if s.opts.Notation != nil {
+		tokens = tokenize(NewLexer(scannedGame.Raw, WithRawMoves()))
+		parserOpts = append(parserOpts, WithDecoder(s.opts.Notation))
+	} else {
+		var err error
+		if tokens, err = TokenizeGame(scannedGame); err != nil {
+			return nil, err
+		}
+	}
+	parser := NewParser(tokens, parserOpts...)
+	game, err := parser.Parse()
+	if err != nil {
+		var perr *ParserError
+		if errors.As(err, &perr) {
+			perr.locate(scannedGame)
+		}
+		return nil, err
+	}
+	for i := range game.warnings {
+		game.warnings[i].locate(scannedGame)
+	}
+	return game, nil
+}
+
+// scanned returns the game last scanned by the underlying scanner.
+func (s *Scanner) scanned() *GameScanned {
+	game := &GameScanned{
+		Raw:    s.opts.Encoding.decode(s.scanner.Bytes()),
+		Index:  s.count,
+		Offset: s.tokenLoc.offset,
+		Line:   s.tokenLoc.line,
+		Column: s.tokenLoc.column,
+	}
+	s.count++
+	return game
+}
+
+// split wraps splitPGNGames to keep track of the location of the games
+// in the source and to skip a leading byte order mark.
+func (s *Scanner) split(data []byte, atEOF bool) (int, []byte, error) {
+	if s.loc.offset == 0 {
+		if bytes.HasPrefix(data, []byte(utf8BOM)) {
+			s.loc.offset = int64(len(utf8BOM))
+			return len(utf8BOM), nil, nil
+		}
+		if !atEOF && len(data) < len(utf8BOM) && strings.HasPrefix(utf8BOM, string(data)) {
+			return 0, nil, nil // need more data to tell
+		}
+	}
+	advance, token, err := splitPGNGames(data, atEOF)
+	if len(token) > 0 {
+		// token is a subslice of data
+		s.tokenLoc = s.loc
+		s.tokenLoc.advance(data[:cap(data)-cap(token)])
+	}
+	s.loc.advance(data[:advance])
+	return advance, token, err
+}
+
+// locate converts the location of e, relative to the game, into a
+// location in the source of the scanner.
+func (e *ParserError) locate(game *GameScanned) {
+	e.GameIndex = game.Index
+	if e.Line == 0 {
+		return
+	}
+	if e.Line == 1 {
+		e.Column += game.Column - 1
+	}
+	e.Line += game.Line - 1
+	e.Offset += int(game.Offset)
+}
+
 // Split function for bufio.Scanner to split PGN games.
 func splitPGNGames(data []byte, atEOF bool) (int, []byte, error) {
 	// Skip leading whitespace
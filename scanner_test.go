@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestScanner(t *testing.T) {
@@ -328,3 +329,253 @@ func TestScannerMultiFromPosNoExpand(t *testing.T) {
 	scanner := NewScanner(reader)
 	validateExpand(t, scanner, expectedLastLines, expectedFinalPos)
 }
+
+func TestScannerGameLocation(t *testing.T) {
+	pgn := "[Event \"A\"]\n\n1. e4 e5 1-0\n\n[Event \"B\"]\n\n1. d4 d5 0-1\n"
+	scanner := NewScanner(strings.NewReader(pgn))
+
+	expected := []GameScanned{
+		{Index: 0, Offset: 0, Line: 1, Column: 1},
+		{Index: 1, Offset: 27, Line: 5, Column: 1},
+	}
+	for _, exp := range expected {
+		game, err := scanner.ScanGame()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if game.Index != exp.Index || game.Offset != exp.Offset || game.Line != exp.Line || game.Column != exp.Column {
+			t.Errorf("expected game at %d/%d/%d:%d, got %d/%d/%d:%d",
+				exp.Index, exp.Offset, exp.Line, exp.Column, game.Index, game.Offset, game.Line, game.Column)
+		}
+		if pgn[game.Offset] != '[' {
+			t.Errorf("expected game %d to start with a tag, got %q", game.Index, pgn[game.Offset])
+		}
+	}
+}
+
+func TestScannerParseErrorLocation(t *testing.T) {
+	pgn := "[Event \"A\"]\n\n1. e4 e5 1-0\n\n[Event \"B\"]\n\n1. d4 d5\n2. Ke3 0-1\n"
+	scanner := NewScanner(strings.NewReader(pgn))
+
+	if _, err := scanner.ParseNext(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := scanner.ParseNext()
+	var perr *ParserError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a ParserError, got %v", err)
+	}
+	if perr.GameIndex != 1 || perr.Line != 8 || perr.Column != 4 || perr.MoveNumber != 2 {
+		t.Errorf("expected error in game 1 at 8:4 on move 2, got %+v", perr)
+	}
+	if perr.TokenValue != "Ke3" {
+		t.Errorf("expected token value Ke3, got %q", perr.TokenValue)
+	}
+	if got := pgn[perr.Offset : perr.Offset+3]; got != "Ke3" {
+		t.Errorf("expected offset to point at Ke3, got %q", got)
+	}
+}
+
+func TestParseInvalidFENError(t *testing.T) {
+	pgn := "[Event \"A\"]\n[FEN \"not a fen\"]\n\n1. e4 *\n"
+	_, err := NewScanner(strings.NewReader(pgn)).ParseNext()
+	var perr *ParserError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a ParserError, got %v", err)
+	}
+	if perr.Line != 2 || perr.Column != 6 || perr.Err == nil {
+		t.Errorf("expected FEN error at 2:6 wrapping the FEN error, got %+v", perr)
+	}
+}
+
+func TestScannerLenientParsing(t *testing.T) {
+	tests := []struct {
+		name        string
+		pgn         string
+		moves       string
+		outcome     Outcome
+		warnings    int
+		strictFails bool
+	}{
+		{
+			name:     "castling with zeros",
+			pgn:      "[Event \"A\"]\n\n1. e4 e5 2. Nf3 Nc6 3. Bc4 Bc5 4. 0-0 Nf6 1-0",
+			moves:    "1. e4 e5 2. Nf3 Nc6 3. Bc4 Bc5 4. O-O Nf6 1-0",
+			outcome:  WhiteWon,
+			warnings: 1,
+		},
+		{
+			name:        "promotion without equal sign",
+			pgn:         "[Event \"A\"]\n[FEN \"8/4P1k1/8/8/8/8/8/4K3 w - - 0 1\"]\n\n1. e8Q *",
+			moves:       "1. e8=Q *",
+			outcome:     NoOutcome,
+			warnings:    1,
+			strictFails: true,
+		},
+		{
+			name:        "stray text and repeated check",
+			pgn:         "[Event \"A\"]\n\n1. e4 e5 2. Nf3+!?+ foo Nc6 1/2-1/2",
+			moves:       "1. e4 e5 2. Nf3+ Nc6 1/2-1/2",
+			outcome:     Draw,
+			warnings:    1,
+			strictFails: true,
+		},
+		{
+			name:     "missing result",
+			pgn:      "[Event \"A\"]\n\n1. e4 e5",
+			moves:    "1. e4 e5 *",
+			outcome:  NoOutcome,
+			warnings: 1,
+		},
+		{
+			name:        "illegal move truncates the line",
+			pgn:         "[Event \"A\"]\n\n1. e4 e5 2. Qxh7 Nc6 3. d4 0-1",
+			moves:       "1. e4 e5 0-1",
+			outcome:     BlackWon,
+			warnings:    1,
+			strictFails: true,
+		},
+		{
+			name:        "illegal move in variation",
+			pgn:         "[Event \"A\"]\n\n1. e4 (1. d4 Kd5 2. c4) 1... e5 1-0",
+			moves:       "1. e4 (1. d4) 1... e5 1-0",
+			outcome:     WhiteWon,
+			warnings:    1,
+			strictFails: true,
+		},
+		{
+			name:     "invalid UTF-8 in comment",
+			pgn:      "[Event \"A\"]\n\n1. e4 {caf\xe9} e5 1-0",
+			moves:    "1. e4 {caf�} e5 1-0",
+			outcome:  WhiteWon,
+			warnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewScanner(strings.NewReader(tt.pgn)).ParseNext(); tt.strictFails && err == nil {
+				t.Errorf("expected strict parsing to fail")
+			}
+
+			game, err := NewScanner(strings.NewReader(tt.pgn), WithLenientParsing()).ParseNext()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := movetext(game); got != tt.moves {
+				t.Errorf("expected moves %q, got %q", tt.moves, got)
+			}
+			if game.Outcome() != tt.outcome {
+				t.Errorf("expected outcome %s, got %s", tt.outcome, game.Outcome())
+			}
+			if warnings := game.Warnings(); len(warnings) != tt.warnings {
+				t.Errorf("expected %d warnings, got %d: %v", tt.warnings, len(warnings), warnings)
+			}
+		})
+	}
+}
+
+func TestScannerLenientWarningLocation(t *testing.T) {
+	pgn := "[Event \"A\"]\n\n1. e4 e5 1-0\n\n[Event \"B\"]\n\n1. e4 e5 2. Nf3 Nc6 3. Bc4 Bc5\n4. 0-0 Nf6 0-1\n"
+	scanner := NewScanner(strings.NewReader(pgn), WithLenientParsing())
+	if _, err := scanner.ParseNext(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	game, err := scanner.ParseNext()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	warnings := game.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %v", warnings)
+	}
+	if w := warnings[0]; w.GameIndex != 1 || w.Line != 8 || w.Column != 4 {
+		t.Errorf("expected warning in game 1 at 8:4, got %+v", w)
+	}
+}
+
+func TestScannerEncoding(t *testing.T) {
+	latin1 := "[Event \"Karlsbad\"]\n[White \"Nimzowitsch, Aron\"]\n[Black \"R\xe9ti, Richard\"]\n\n1. e4 {Fran\xe7ais \x80} e6 *\n"
+	utf8Game := "[Event \"Karlsbad\"]\n[White \"Nimzowitsch, Aron\"]\n[Black \"Réti, Richard\"]\n\n1. e4 {Français €} e6 *\n"
+
+	tests := []struct {
+		name    string
+		input   string
+		enc     Encoding
+		black   string
+		comment string
+	}{
+		{"latin-1", latin1, EncodingLatin1, "Réti, Richard", "Français \u0080"},
+		{"windows-1252", latin1, EncodingWindows1252, "Réti, Richard", "Français €"},
+		{"auto latin-1", latin1, EncodingAuto, "Réti, Richard", "Français €"},
+		{"auto utf-8", utf8Game, EncodingAuto, "Réti, Richard", "Français €"},
+		{"utf-8 bom", utf8BOM + utf8Game, EncodingUTF8, "Réti, Richard", "Français €"},
+		{"latin-1 bom", utf8BOM + latin1, EncodingWindows1252, "Réti, Richard", "Français €"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := NewScanner(strings.NewReader(tt.input), WithEncoding(tt.enc))
+			game, err := scanner.ParseNext()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if black := game.GetTagPair("Black"); black != tt.black {
+				t.Errorf("expected Black %q, got %q", tt.black, black)
+			}
+			if comment := game.Moves()[0].Comments(); comment != tt.comment {
+				t.Errorf("expected comment %q, got %q", tt.comment, comment)
+			}
+			if !utf8.ValidString(game.String()) {
+				t.Errorf("expected valid UTF-8, got %q", game.String())
+			}
+		})
+	}
+}
+
+func TestScannerBOMTaglessGame(t *testing.T) {
+	scanner := NewScanner(strings.NewReader(utf8BOM + "1. e4 e5 *\n"))
+	game, err := scanner.ParseNext()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(game.Moves()) != 2 {
+		t.Errorf("expected 2 moves, got %d", len(game.Moves()))
+	}
+}
+
+// movetext returns the movetext of the game as written by String.
+func movetext(g *Game) string {
+	s := g.String()
+	if i := strings.Index(s, "\n\n"); i >= 0 {
+		return s[i+2:]
+	}
+	return s
+}
+
+func TestScannerNotation(t *testing.T) {
+	pgn := `[Event "Descriptive"]
+[Result "*"]
+
+1. P-K4 P-K4 2. Kt-KB3 N-QB3 3. B-N5 {Ruy Lopez} (3. B-B4 B-B4) 3... P-QR3 4. BxN QPxB 5. O-O P-B3 *
+
+[Event "Broken"]
+[Result "*"]
+
+1. P-K4 P-K5 *
+`
+	scanner := NewScanner(strings.NewReader(pgn), WithNotation(DescriptiveNotation{}))
+	game, err := scanner.ParseNext()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "1. e4 e5 2. Nf3 Nc6 3. Bb5 {Ruy Lopez} (3. Bc4 Bc5) 3... a6 4. Bxc6 dxc6 5. O-O f6 *"
+	if got := strings.TrimSpace(movetext(game)); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+
+	_, err = scanner.ParseNext()
+	var perr *ParserError
+	if !errors.As(err, &perr) || perr.Err == nil || perr.TokenValue != "P-K5" {
+		t.Fatalf("expected an invalid move error on P-K5, got %v", err)
+	}
+}
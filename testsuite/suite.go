@@ -0,0 +1,227 @@
+// Package testsuite runs engine test suites, such as WAC or STS, written in
+// EPD. Each position is searched by a UCI engine with a fixed budget and the
+// engine's best move is checked against the bm (best move) and am (avoid
+// move) operations of the record.
+//
+// Example:
+//
+//	positions, err := testsuite.Load(f)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	eng, err := uci.New("stockfish")
+//	if err != nil {
+//	    panic(err)
+//	}
+//	defer eng.Close()
+//	r := testsuite.Runner{Engine: eng, MoveTime: time.Second}
+//	report, err := r.Run(positions)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	fmt.Printf("%d/%d solved\n", report.Solved, report.Total)
+package testsuite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/corentings/chess/v2"
+	"github.com/corentings/chess/v2/uci"
+)
+
+// Load reads the EPD records of a test suite, one per line. Reading stops
+// at the first record that can't be parsed.
+func Load(r io.Reader) ([]*chess.EPD, error) {
+	var positions []*chess.EPD
+	reader := chess.NewEPDReader(r)
+	for {
+		e, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return positions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, e)
+	}
+}
+
+// Runner searches the positions of a test suite with an engine. At least
+// one of MoveTime and Depth should be set; when both are, the engine stops
+// at the first limit reached.
+type Runner struct {
+	Engine   *uci.Engine
+	MoveTime time.Duration // search time per position
+	Depth    int           // search depth per position
+	// OnResult, if not nil, is called with the result of each position as
+	// soon as it is known, e.g. to report progress.
+	OnResult func(Result)
+}
+
+// Result is the outcome of the search of a position.
+type Result struct {
+	ID         string   `json:"id,omitempty"`
+	FEN        string   `json:"fen"`
+	BestMoves  []string `json:"bm,omitempty"` // SAN
+	AvoidMoves []string `json:"am,omitempty"` // SAN
+	Move       string   `json:"move,omitempty"`
+	Solved     bool     `json:"solved"`
+	// TimeToSolution is the search time from which the engine's main line
+	// started with a solving move and kept doing so, 0 if unsolved.
+	TimeToSolution time.Duration `json:"time_to_solution"`
+	Depth          int           `json:"depth"`
+	Nodes          int           `json:"nodes"`
+	ScoreCP        int           `json:"score_cp"`
+	ScoreMate      int           `json:"score_mate,omitempty"`
+	Error          string        `json:"error,omitempty"`
+}
+
+// Report is the outcome of a test suite run. Durations are written to
+// JSON in nanoseconds.
+type Report struct {
+	Engine    string        `json:"engine,omitempty"`
+	MoveTime  time.Duration `json:"move_time,omitempty"`
+	Depth     int           `json:"depth,omitempty"`
+	Total     int           `json:"total"`
+	Solved    int           `json:"solved"`
+	SolveRate float64       `json:"solve_rate"` // from 0 to 1
+	// TimeToSolution is the sum of the times to solution of the solved
+	// positions.
+	TimeToSolution time.Duration `json:"time_to_solution"`
+	Elapsed        time.Duration `json:"elapsed"`
+	Results        []Result      `json:"results"`
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Run initializes the engine and searches each position. Positions without
+// a bm or am operation are reported unsolved with an error. An error is
+// returned only when the engine fails.
+func (r *Runner) Run(positions []*chess.EPD) (*Report, error) {
+	if r.Engine == nil {
+		return nil, errors.New("testsuite: no engine")
+	}
+	if err := r.Engine.Run(uci.CmdUCI, uci.CmdIsReady); err != nil {
+		return nil, fmt.Errorf("testsuite: %w", err)
+	}
+	report := &Report{
+		Engine:   r.Engine.ID()["name"],
+		MoveTime: r.MoveTime,
+		Depth:    r.Depth,
+		Results:  make([]Result, 0, len(positions)),
+	}
+	start := time.Now()
+	for _, e := range positions {
+		res, err := r.search(e)
+		if err != nil {
+			return nil, fmt.Errorf("testsuite: %s: %w", e.Position, err)
+		}
+		report.Total++
+		if res.Solved {
+			report.Solved++
+			report.TimeToSolution += res.TimeToSolution
+		}
+		report.Results = append(report.Results, res)
+		if r.OnResult != nil {
+			r.OnResult(res)
+		}
+	}
+	report.Elapsed = time.Since(start)
+	if report.Total > 0 {
+		report.SolveRate = float64(report.Solved) / float64(report.Total)
+	}
+	return report, nil
+}
+
+// search searches a position and checks the engine's best move.
+func (r *Runner) search(e *chess.EPD) (Result, error) {
+	pos := e.Position
+	bm, am := e.Moves(chess.EPDBestMove), e.Moves(chess.EPDAvoidMove)
+	res := Result{
+		ID:         e.Text(chess.EPDID),
+		FEN:        pos.String(),
+		BestMoves:  sanMoves(pos, bm),
+		AvoidMoves: sanMoves(pos, am),
+	}
+	if len(bm) == 0 && len(am) == 0 {
+		res.Error = "no bm or am operation"
+		return res, nil
+	}
+
+	started := time.Now()
+	cmdPos := uci.CmdPosition{Position: pos}
+	cmdGo := uci.CmdGo{MoveTime: r.MoveTime, Depth: r.Depth}
+	if err := r.Engine.Run(uci.CmdUCINewGame, uci.CmdIsReady, cmdPos, cmdGo); err != nil {
+		return res, err
+	}
+	elapsed := time.Since(started)
+	results := r.Engine.SearchResults()
+	res.Depth = results.Info.Depth
+	res.Nodes = results.Info.Nodes
+	res.ScoreCP = results.Info.Score.CP
+	res.ScoreMate = results.Info.Score.Mate
+
+	best := legal(pos, results.BestMove)
+	if best == nil {
+		res.Error = fmt.Sprintf("illegal best move %v", results.BestMove)
+		return res, nil
+	}
+	res.Move = chess.AlgebraicNotation{}.Encode(pos, best)
+	solves := func(m *chess.Move) bool {
+		return (len(bm) == 0 || contains(bm, m)) && !contains(am, m)
+	}
+	res.Solved = solves(best)
+	if res.Solved {
+		res.TimeToSolution = timeToSolution(results.History, solves, elapsed)
+	}
+	return res, nil
+}
+
+// timeToSolution returns the time of the first info of the history from
+// which every main line starts with a solving move. When the engine sent no
+// main line, the elapsed time is returned.
+func timeToSolution(history []uci.Info, solves func(*chess.Move) bool, elapsed time.Duration) time.Duration {
+	t := elapsed
+	for i := len(history) - 1; i >= 0; i-- {
+		if !solves(history[i].PV[0]) {
+			break
+		}
+		t = history[i].Time
+	}
+	return t
+}
+
+// legal returns the valid move of pos matching m, or nil.
+func legal(pos *chess.Position, m *chess.Move) *chess.Move {
+	if m == nil {
+		return nil
+	}
+	moves := pos.ValidMoves()
+	i := slices.IndexFunc(moves, func(vm chess.Move) bool { return vm.String() == m.String() })
+	if i < 0 {
+		return nil
+	}
+	return &moves[i]
+}
+
+func contains(moves []*chess.Move, m *chess.Move) bool {
+	return slices.ContainsFunc(moves, func(c *chess.Move) bool { return c.String() == m.String() })
+}
+
+func sanMoves(pos *chess.Position, moves []*chess.Move) []string {
+	var s []string
+	for _, m := range moves {
+		s = append(s, chess.AlgebraicNotation{}.Encode(pos, m))
+	}
+	return s
+}
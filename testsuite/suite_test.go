@@ -0,0 +1,154 @@
+package testsuite_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corentings/chess/v2/testsuite"
+	"github.com/corentings/chess/v2/uci"
+)
+
+// fakeEngineEnv makes the test binary behave as a scripted UCI engine, see
+// TestMain.
+const fakeEngineEnv = "TESTSUITE_FAKE_ENGINE"
+
+const suite = `2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - bm Qg6; id "WAC.001";
+8/7p/5k2/5p2/p1p2P2/Pr1pPK2/1P1R3P/8 b - - bm Rxb2; id "WAC.002";
+5rk1/1ppb3p/p1pb4/6q1/3P1p1r/2P1R2P/PP1BQ1P1/5RKN w - - am Rg3; id "WAC.003";
+r1bqkbnr/pppp1ppp/2n5/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R w KQkq - id "no operation";
+`
+
+// fakeSearches maps the FEN sent with the position command to the lines
+// the engine writes in answer to go.
+//
+//nolint:gochecknoglobals // test script.
+var fakeSearches = map[string][]string{
+	"2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - 0 1": {
+		"info depth 1 score cp 50 time 10 nodes 100 pv e5f7",
+		"info depth 2 score cp 900 time 40 nodes 900 pv g3g6 f7g6",
+		"info depth 3 score mate 2 time 90 nodes 4000 pv g3g6 f7g6 e5g6",
+		"bestmove g3g6 ponder f7g6",
+	},
+	"8/7p/5k2/5p2/p1p2P2/Pr1pPK2/1P1R3P/8 b - - 0 1": {
+		"info depth 5 score cp -20 time 30 nodes 500 pv b3b2",
+		"info depth 6 score cp 10 time 60 nodes 700 pv c4c3",
+		"bestmove c4c3",
+	},
+	"5rk1/1ppb3p/p1pb4/6q1/3P1p1r/2P1R2P/PP1BQ1P1/5RKN w - - 0 1": {
+		"bestmove e3f3",
+	},
+}
+
+// TestMain runs the fake engine when the test binary is started by a test
+// as an engine.
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeEngineEnv) != "" {
+		runFakeEngine()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func runFakeEngine() {
+	var fen string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "uci":
+			fmt.Println("id name Fake Engine")
+			fmt.Println("uciok")
+		case line == "isready":
+			fmt.Println("readyok")
+		case strings.HasPrefix(line, "position fen "):
+			fen = strings.TrimPrefix(line, "position fen ")
+		case strings.HasPrefix(line, "go"):
+			for _, s := range fakeSearches[fen] {
+				fmt.Println(s)
+			}
+		case line == "quit":
+			return
+		}
+	}
+}
+
+func TestRunner(t *testing.T) {
+	positions, err := testsuite.Load(strings.NewReader(suite))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(fakeEngineEnv, "1")
+	eng, err := uci.New(os.Args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eng.Close()
+
+	var seen []string
+	r := testsuite.Runner{
+		Engine:   eng,
+		MoveTime: 100 * time.Millisecond,
+		OnResult: func(res testsuite.Result) { seen = append(seen, res.ID) },
+	}
+	report, err := r.Run(positions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Engine != "Fake Engine" || report.Total != 4 || report.Solved != 2 || report.SolveRate != 0.5 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if strings.Join(seen, ",") != "WAC.001,WAC.002,WAC.003,no operation" {
+		t.Errorf("unexpected progress %v", seen)
+	}
+	expected := []struct {
+		move           string
+		solved         bool
+		timeToSolution time.Duration
+	}{
+		{"Qg6", true, 40 * time.Millisecond},
+		{"c3", false, 0},
+		{"Ref3", true, 0}, // no main line, the elapsed time is used
+		{"", false, 0},
+	}
+	for i, exp := range expected {
+		res := report.Results[i]
+		if res.Move != exp.move || res.Solved != exp.solved {
+			t.Errorf("%s: expected %s solved=%v, got %+v", res.ID, exp.move, exp.solved, res)
+		}
+		if exp.timeToSolution > 0 && res.TimeToSolution != exp.timeToSolution {
+			t.Errorf("%s: expected a time to solution of %v, got %v", res.ID, exp.timeToSolution, res.TimeToSolution)
+		}
+	}
+	if res := report.Results[0]; res.Depth != 3 || res.ScoreMate != 2 || res.BestMoves[0] != "Qg6" {
+		t.Errorf("unexpected search details %+v", res)
+	}
+	if res := report.Results[3]; res.Error == "" {
+		t.Errorf("expected an error for a position without operation, got %+v", res)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded testsuite.Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Solved != 2 || len(decoded.Results) != 4 || decoded.Results[0].TimeToSolution != 40*time.Millisecond {
+		t.Errorf("unexpected JSON report %s", buf.String())
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	if _, err := testsuite.Load(strings.NewReader("8/8/8/8 w - - bm Qa1;\n")); err == nil {
+		t.Error("expected an error")
+	}
+}
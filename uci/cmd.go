@@ -348,6 +348,9 @@ func (CmdGo) ProcessResponse(e *Engine) error {
 
 		if info.Multipv == 0 || info.Multipv == 1 {
 			results.Info = *info
+			if len(info.PV) > 0 {
+				results.History = append(results.History, *info)
+			}
 		}
 
 		if info.Multipv > 1 && info.Multipv < 300 {
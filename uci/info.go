@@ -20,6 +20,10 @@ type SearchResults struct {
 	Ponder      *chess.Move
 	MultiPVInfo []Info
 	Info        Info
+	// History holds the infos of the main line carrying a pv, in the
+	// order they were received. It shows how the best line changed during
+	// the search.
+	History []Info
 }
 
 // Info corresponds to the "info" engine output: